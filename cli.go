@@ -0,0 +1,428 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ur-wesley/modhelper/internal"
+	"github.com/ur-wesley/modhelper/internal/config"
+	"github.com/ur-wesley/modhelper/internal/profile"
+	"github.com/ur-wesley/modhelper/internal/support"
+	"github.com/ur-wesley/modhelper/internal/updater"
+)
+
+func runCommand(args []string) int {
+	switch args[0] {
+	case "manifest":
+		return runManifestCommand(args[1:])
+	case "profile":
+		return runProfileCommand(args[1:])
+	case "release":
+		return runReleaseCommand(args[1:])
+	case "support":
+		if len(args) < 2 || args[1] != "bundle" || len(args) > 3 {
+			printUsage()
+			return 2
+		}
+		output := support.BundleName(time.Now())
+		if len(args) == 3 {
+			output = args[2]
+		}
+		return createSupportBundle(output)
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command: %s\n", args[0])
+		printUsage()
+		return 2
+	}
+}
+
+func printUsage() {
+	fmt.Fprintln(os.Stderr, "usage:")
+	fmt.Fprintln(os.Stderr, "  modhelper [--admin]")
+	fmt.Fprintln(os.Stderr, "  modhelper manifest lint <file>")
+	fmt.Fprintln(os.Stderr, "  modhelper profile export [--manifest <file|url>] [--variant <name>] [--url <url>] [--version <version>] [--code] <game> <output.r2z>")
+	fmt.Fprintln(os.Stderr, "  modhelper profile import [--manifest <file|url>] [--profile <name>] <game> <code>")
+	fmt.Fprintln(os.Stderr, "  modhelper profile verify [--manifest <file|url>] [--variant <name>] <game>")
+	fmt.Fprintln(os.Stderr, "  modhelper profile repair [--manifest <file|url>] [--variant <name>] <game>")
+	fmt.Fprintln(os.Stderr, "  modhelper support bundle [output.zip]")
+	fmt.Fprintln(os.Stderr, "  modhelper release keygen")
+	fmt.Fprintln(os.Stderr, "  modhelper release checksums <output> <file>...")
+	fmt.Fprintln(os.Stderr, "  modhelper release sign <checksums>")
+}
+
+func runManifestCommand(args []string) int {
+	if len(args) == 0 {
+		printUsage()
+		return 2
+	}
+
+	switch args[0] {
+	case "lint":
+		if len(args) != 2 {
+			printUsage()
+			return 2
+		}
+		return lintManifest(args[1])
+	default:
+		fmt.Fprintf(os.Stderr, "unknown manifest command: %s\n", args[0])
+		printUsage()
+		return 2
+	}
+}
+
+func lintManifest(path string) int {
+	manifest, err := profile.LoadManifestFile(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", path, err)
+		return 1
+	}
+
+	errs := profile.ValidateManifest(manifest)
+	for _, validationErr := range errs {
+		fmt.Printf("%s: %v\n", path, validationErr)
+	}
+
+	if len(errs) > 0 {
+		fmt.Printf("%s: %d problem(s) found\n", path, len(errs))
+		return 1
+	}
+
+	fmt.Printf("%s: schema v%d, %d game(s), no problems found\n", path, manifest.SchemaVersion, len(manifest.Games))
+	return 0
+}
+
+func runProfileCommand(args []string) int {
+	if len(args) == 0 {
+		printUsage()
+		return 2
+	}
+
+	switch args[0] {
+	case "export":
+		return exportProfile(args[1:])
+	case "import":
+		return importProfileCode(args[1:])
+	case "verify":
+		return verifyProfile(args[1:], false)
+	case "repair":
+		return verifyProfile(args[1:], true)
+	default:
+		fmt.Fprintf(os.Stderr, "unknown profile command: %s\n", args[0])
+		printUsage()
+		return 2
+	}
+}
+
+func exportProfile(args []string) int {
+	flags := flag.NewFlagSet("profile export", flag.ContinueOnError)
+	manifestSource := flags.String("manifest", "", "manifest file or URL (defaults to the configured manifest)")
+	variantName := flags.String("variant", "", "profile variant to export")
+	downloadURL := flags.String("url", "", "download URL to put into the manifest snippet")
+	version := flags.String("version", "", "version to put into the manifest snippet (defaults to the installed version)")
+	createCode := flags.Bool("code", false, "upload the profile to Thunderstore and print a profile code")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() != 2 {
+		printUsage()
+		return 2
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to load config: %v\n", err)
+		return 1
+	}
+
+	game, err := findManifestGame(cfg, *manifestSource, flags.Arg(0), *variantName)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return 1
+	}
+
+	store := profile.NewProfileStore(cfg)
+
+	export, err := store.ExportProfile(game)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to export %s: %v\n", game.Name, err)
+		return 1
+	}
+
+	outputPath := flags.Arg(1)
+	if err := export.WriteFile(outputPath); err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return 1
+	}
+	fmt.Printf("Exported %s (%d mods, %d config files) to %s\n", export.ProfileName, export.ModCount, export.ConfigCount, outputPath)
+	fmt.Printf("sha256: %s\n", export.SHA256)
+
+	if *createCode {
+		code, err := store.CreateProfileCode(export)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to create profile code: %v\n", err)
+			return 1
+		}
+		fmt.Printf("profile code: %s\n", code)
+	}
+
+	snippetVersion := *version
+	if snippetVersion == "" {
+		snippetVersion, err = store.GetInstalledProfileVersion(game)
+		if err != nil || snippetVersion == "" {
+			snippetVersion = game.Version
+		}
+	}
+
+	snippet, err := profile.ManifestSnippet(game, export, *downloadURL, snippetVersion)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return 1
+	}
+	fmt.Printf("manifest entry:\n%s\n", snippet)
+	if *downloadURL == "" {
+		fmt.Printf("Upload %s and set \"url\" to its download link before publishing.\n", outputPath)
+	}
+	return 0
+}
+
+func importProfileCode(args []string) int {
+	flags := flag.NewFlagSet("profile import", flag.ContinueOnError)
+	manifestSource := flags.String("manifest", "", "manifest file or URL (defaults to the configured manifest)")
+	profileName := flags.String("profile", "", "name of the new profile (defaults to the name stored in the code)")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() != 2 {
+		printUsage()
+		return 2
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to load config: %v\n", err)
+		return 1
+	}
+
+	game, err := findManifestGame(cfg, *manifestSource, flags.Arg(0), "")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return 1
+	}
+	game.ProfileName = *profileName
+
+	imported, err := profile.NewProfileStore(cfg).ImportProfileCode(game, flags.Arg(1))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to import profile code: %v\n", err)
+		return 1
+	}
+
+	fmt.Printf("Imported profile %s for %s\n", imported, game.Name)
+	return 0
+}
+
+func verifyProfile(args []string, repair bool) int {
+	name := "profile verify"
+	if repair {
+		name = "profile repair"
+	}
+
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	manifestSource := flags.String("manifest", "", "manifest file or URL (defaults to the configured manifest)")
+	variantName := flags.String("variant", "", "profile variant to check")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() != 1 {
+		printUsage()
+		return 2
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to load config: %v\n", err)
+		return 1
+	}
+
+	game, err := findManifestGame(cfg, *manifestSource, flags.Arg(0), *variantName)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return 1
+	}
+
+	store := profile.NewProfileStore(cfg)
+
+	var result *profile.VerifyResult
+	if repair {
+		result, err = store.Repair(game)
+	} else {
+		result, err = store.Verify(game)
+	}
+	if result == nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return 1
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "repair incomplete: %v\n", err)
+	}
+
+	for _, problem := range result.Problems {
+		switch {
+		case problem.Path != "" && problem.Mod != "":
+			fmt.Printf("%s: %s (%s)\n", problem.Kind, problem.Path, problem.Mod)
+		case problem.Path != "":
+			fmt.Printf("%s: %s\n", problem.Kind, problem.Path)
+		default:
+			fmt.Printf("%s: %s\n", problem.Kind, problem.Mod)
+		}
+	}
+	if !result.Indexed {
+		fmt.Println("No installed files were recorded for this profile; only loader files were checked. Reinstall it to enable full verification.")
+	}
+
+	if !result.OK() {
+		fmt.Printf("%s: %d problem(s) in %d checked file(s)\n", game.Name, len(result.Problems), result.Checked)
+		return 1
+	}
+	fmt.Printf("%s: %d file(s) checked, no problems found\n", game.Name, result.Checked)
+	return 0
+}
+
+func createSupportBundle(output string) int {
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to load config: %v\n", err)
+		return 1
+	}
+
+	file, err := os.Create(output)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return 1
+	}
+
+	err = support.WriteBundle(file, cfg, profile.NewProfileStore(cfg))
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to create support bundle: %v\n", err)
+		os.Remove(output)
+		return 1
+	}
+
+	fmt.Printf("Support bundle written to %s\n", output)
+	return 0
+}
+
+func runReleaseCommand(args []string) int {
+	if len(args) == 0 {
+		printUsage()
+		return 2
+	}
+
+	switch args[0] {
+	case "keygen":
+		publicKey, privateKey, err := updater.GenerateSigningKey()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to generate signing key: %v\n", err)
+			return 1
+		}
+		fmt.Printf("public key:  %s\n", publicKey)
+		fmt.Printf("private key: %s\n", privateKey)
+		fmt.Println("Store the private key as the UPDATE_SIGNING_KEY secret and the public key as UPDATE_SIGNING_PUBLIC_KEY.")
+		return 0
+	case "checksums":
+		if len(args) < 3 {
+			printUsage()
+			return 2
+		}
+		checksums, err := updater.FormatChecksums(args[2:])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			return 1
+		}
+		if err := os.WriteFile(args[1], checksums, 0644); err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			return 1
+		}
+		fmt.Print(string(checksums))
+		return 0
+	case "sign":
+		if len(args) != 2 {
+			printUsage()
+			return 2
+		}
+		return signChecksums(args[1])
+	default:
+		fmt.Fprintf(os.Stderr, "unknown release command: %s\n", args[0])
+		printUsage()
+		return 2
+	}
+}
+
+func signChecksums(path string) int {
+	privateKey := os.Getenv("UPDATE_SIGNING_KEY")
+	if privateKey == "" {
+		fmt.Fprintln(os.Stderr, "UPDATE_SIGNING_KEY is not set")
+		return 1
+	}
+
+	checksums, err := os.ReadFile(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return 1
+	}
+
+	signature, err := updater.SignChecksums(privateKey, checksums)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return 1
+	}
+
+	signaturePath := path + ".sig"
+	if err := os.WriteFile(signaturePath, signature, 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return 1
+	}
+	fmt.Printf("Signed %s -> %s\n", path, signaturePath)
+	return 0
+}
+
+func findManifestGame(cfg *internal.Config, source, name, variantName string) (internal.Game, error) {
+	if source == "" {
+		source = cfg.ManifestURL
+	}
+
+	var manifest *internal.Manifest
+	var err error
+	if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+		manifest, err = profile.FetchManifest(source, config.GetHTTPTimeout(cfg))
+	} else {
+		manifest, err = profile.LoadManifestFile(source)
+	}
+	if err != nil {
+		return internal.Game{}, fmt.Errorf("%s: %w", source, err)
+	}
+
+	for _, game := range manifest.Games {
+		if !strings.EqualFold(game.Name, name) && game.ID != name {
+			continue
+		}
+
+		variants := game.ProfileVariants()
+		if variantName == "" {
+			return game.WithVariant(variants[0]), nil
+		}
+		for _, variant := range variants {
+			if strings.EqualFold(variant.Name, variantName) {
+				return game.WithVariant(variant), nil
+			}
+		}
+		return internal.Game{}, fmt.Errorf("game %s has no variant %s", game.Name, variantName)
+	}
+
+	return internal.Game{}, fmt.Errorf("game %s not found in %s", name, source)
+}
@@ -2,70 +2,252 @@ package config
 
 import (
 	"encoding/json"
-	"log"
+	"errors"
+	"fmt"
+	"log/slog"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/ur-wesley/modhelper/internal"
+	"github.com/ur-wesley/modhelper/internal/logging"
+	"github.com/ur-wesley/modhelper/internal/r2modman"
 )
 
 const (
 	DefaultManifestURL  = "https://gist.githubusercontent.com/ur-wesley/8e93a37dc70b7d8161e94fc62df061ee/raw/manifest.json"
 	R2ModmanDownloadURL = "https://r2modman.net/download/latest-version/"
+	ThunderstoreURL     = "https://thunderstore.io"
+	UpdateFeedURL       = "https://api.github.com/repos/ur-wesley/modhelper/releases"
 	ConfigFileName      = "config.json"
+	MigratedSuffix      = ".migrated"
+
+	UpdateChannelStable = "stable"
+	UpdateChannelBeta   = "beta"
+
+	LaunchModeSteam  = "steam"
+	LaunchModeDirect = "direct"
+
+	DefaultHTTPTimeoutSeconds     = 60
+	DefaultRetryAttempts          = 3
+	DefaultManifestRefreshSeconds = 30
+	DefaultUpdateCheckHours       = 4
 )
 
+func logger() *slog.Logger {
+	return logging.For("config")
+}
+
+func Dir() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "modhelper"), nil
+}
+
+func Path() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, ConfigFileName), nil
+}
+
+func Default() *internal.Config {
+	return &internal.Config{
+		Version:         CurrentVersion,
+		ManifestURL:     DefaultManifestURL,
+		TargetDir:       GetDefaultProfileDir(),
+		ThunderstoreURL: ThunderstoreURL,
+	}
+}
+
 func Load() (*internal.Config, error) {
-	f, err := os.Open(ConfigFileName)
+	path, err := Path()
 	if err != nil {
-		return &internal.Config{
-			ManifestURL: DefaultManifestURL,
-			TargetDir:   GetDefaultProfileDir(),
-		}, nil
+		return Default(), fmt.Errorf("failed to locate config directory: %w", err)
 	}
-	defer f.Close()
 
-	var c internal.Config
-	if err := json.NewDecoder(f).Decode(&c); err != nil {
-		return nil, err
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return migrateLegacy(path)
 	}
-	return &c, nil
+	if err != nil {
+		return Default(), fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	return decode(path, data)
 }
 
 func Save(c *internal.Config) error {
-	f, err := os.Create(ConfigFileName)
+	if err := Validate(c); err != nil {
+		return err
+	}
+
+	path, err := Path()
 	if err != nil {
+		return fmt.Errorf("failed to locate config directory: %w", err)
+	}
+
+	saved := *c
+	saved.Version = CurrentVersion
+	if err := writeAtomic(path, &saved); err != nil {
 		return err
 	}
-	defer f.Close()
+	c.Version = CurrentVersion
 
-	enc := json.NewEncoder(f)
-	enc.SetIndent("", "  ")
-	return enc.Encode(c)
+	logger().Info("Saved config", "path", path)
+	return nil
 }
 
-func GetDefaultProfileDir() string {
-	appData := os.Getenv("AppData")
-	if appData == "" {
-		return filepath.Join(os.Getenv("HOME"), ".r2modmanPlus-local")
+func decode(path string, data []byte) (*internal.Config, error) {
+	var c internal.Config
+	if err := json.Unmarshal(data, &c); err != nil {
+		return Default(), fmt.Errorf("failed to parse %s: %w", path, err)
 	}
-	return filepath.Join(appData, "r2modmanPlus-local")
-}
 
-func GetGameProfileDir(game internal.Game) string {
-	baseDir := GetDefaultProfileDir()
+	if migrate(&c, GetDefaultProfileDir()) {
+		if err := writeAtomic(path, &c); err != nil {
+			logger().Warn("Could not save migrated config", "path", path, "error", err)
+		}
+	}
 
-	gameDir := strings.ReplaceAll(game.Name, " ", "")
+	return &c, Validate(&c)
+}
 
-	gameDir = strings.TrimRight(gameDir, ".")
+func writeAtomic(path string, c *internal.Config) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode config: %w", err)
+	}
+	data = append(data, '\n')
 
-	profilesDir := filepath.Join(baseDir, gameDir, "profiles")
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
 
-	err := os.MkdirAll(profilesDir, 0755)
+	tmp, err := os.CreateTemp(dir, ConfigFileName+".*.tmp")
 	if err != nil {
-		log.Printf("Warning: Could not create profiles directory %s: %v", profilesDir, err)
+		return fmt.Errorf("failed to create temporary config file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write config: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write config: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write config: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to replace config: %w", err)
+	}
+	return nil
+}
+
+func GetThunderstoreURL(c *internal.Config) string {
+	if c == nil || strings.TrimSpace(c.ThunderstoreURL) == "" {
+		return ThunderstoreURL
+	}
+	return strings.TrimRight(strings.TrimSpace(c.ThunderstoreURL), "/")
+}
+
+func GetUpdateChannel(c *internal.Config) string {
+	if c != nil && strings.EqualFold(strings.TrimSpace(c.UpdateChannel), UpdateChannelBeta) {
+		return UpdateChannelBeta
+	}
+	return UpdateChannelStable
+}
+
+func GetUpdateFeedURL(c *internal.Config) string {
+	if c == nil || strings.TrimSpace(c.UpdateFeedURL) == "" {
+		return UpdateFeedURL
+	}
+	return strings.TrimSpace(c.UpdateFeedURL)
+}
+
+func GetDownloadLimit(c *internal.Config) int64 {
+	if c == nil || c.DownloadLimitKBps <= 0 {
+		return 0
+	}
+	return int64(c.DownloadLimitKBps) * 1024
+}
+
+func GetHTTPTimeout(c *internal.Config) time.Duration {
+	return time.Duration(positiveOr(c, func(c *internal.Config) int { return c.HTTPTimeoutSeconds }, DefaultHTTPTimeoutSeconds)) * time.Second
+}
+
+func GetRetryAttempts(c *internal.Config) int {
+	return positiveOr(c, func(c *internal.Config) int { return c.RetryAttempts }, DefaultRetryAttempts)
+}
+
+func GetManifestRefreshInterval(c *internal.Config) time.Duration {
+	return time.Duration(positiveOr(c, func(c *internal.Config) int { return c.ManifestRefreshSeconds }, DefaultManifestRefreshSeconds)) * time.Second
+}
+
+func GetUpdateCheckInterval(c *internal.Config) time.Duration {
+	return time.Duration(positiveOr(c, func(c *internal.Config) int { return c.UpdateCheckHours }, DefaultUpdateCheckHours)) * time.Hour
+}
+
+func GetLaunchMode(c *internal.Config) string {
+	if c != nil && strings.EqualFold(strings.TrimSpace(c.LaunchMode), LaunchModeDirect) {
+		return LaunchModeDirect
+	}
+	return LaunchModeSteam
+}
+
+func GetGameLaunch(c *internal.Config, game internal.Game) internal.LaunchOptions {
+	options := game.Launch
+	options.Env = make(map[string]string, len(game.Launch.Env))
+	for key, value := range game.Launch.Env {
+		options.Env[key] = value
+	}
+
+	if options.Strategy == "" {
+		options.Strategy = internal.LaunchAppLaunch
+		if GetLaunchMode(c) == LaunchModeDirect {
+			options.Strategy = internal.LaunchDirect
+		}
+	}
+
+	if c == nil {
+		return options
+	}
+	override, ok := c.GameLaunch[game.ID]
+	if !ok {
+		return options
 	}
 
-	return profilesDir
+	if override.Strategy != "" {
+		options.Strategy = override.Strategy
+	}
+	if override.Executable != "" {
+		options.Executable = override.Executable
+	}
+	if override.Command != "" {
+		options.Command = override.Command
+	}
+	for key, value := range override.Env {
+		options.Env[key] = value
+	}
+	return options
+}
+
+func positiveOr(c *internal.Config, field func(*internal.Config) int, fallback int) int {
+	if c == nil || field(c) <= 0 {
+		return fallback
+	}
+	return field(c)
+}
+
+func GetDefaultProfileDir() string {
+	return r2modman.Preferred().DataDir
 }
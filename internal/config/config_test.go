@@ -0,0 +1,117 @@
+package config
+
+import (
+	"maps"
+	"testing"
+
+	"github.com/ur-wesley/modhelper/internal"
+)
+
+func TestGetGameLaunch(t *testing.T) {
+	manifestLaunch := internal.LaunchOptions{
+		Strategy:   internal.LaunchCommand,
+		Executable: "Game.exe",
+		Command:    "${exe} ${args}",
+		Env:        map[string]string{"DOORSTOP_ENABLED": "1", "MODE": "manifest"},
+	}
+
+	tests := []struct {
+		name   string
+		config *internal.Config
+		launch internal.LaunchOptions
+		want   internal.LaunchOptions
+	}{
+		{
+			name: "no config uses applaunch",
+			want: internal.LaunchOptions{Strategy: internal.LaunchAppLaunch, Env: map[string]string{}},
+		},
+		{
+			name:   "direct launch mode",
+			config: &internal.Config{LaunchMode: " Direct "},
+			want:   internal.LaunchOptions{Strategy: internal.LaunchDirect, Env: map[string]string{}},
+		},
+		{
+			name:   "manifest strategy wins over launch mode",
+			config: &internal.Config{LaunchMode: LaunchModeDirect},
+			launch: manifestLaunch,
+			want:   manifestLaunch,
+		},
+		{
+			name: "override for another game is ignored",
+			config: &internal.Config{GameLaunch: map[string]internal.LaunchOptions{
+				"999": {Strategy: internal.LaunchSteamURL},
+			}},
+			launch: manifestLaunch,
+			want:   manifestLaunch,
+		},
+		{
+			name: "user override replaces set fields and merges env",
+			config: &internal.Config{GameLaunch: map[string]internal.LaunchOptions{
+				"123": {
+					Strategy: internal.LaunchDirect,
+					Env:      map[string]string{"MODE": "user", "EXTRA": "1"},
+				},
+			}},
+			launch: manifestLaunch,
+			want: internal.LaunchOptions{
+				Strategy:   internal.LaunchDirect,
+				Executable: "Game.exe",
+				Command:    "${exe} ${args}",
+				Env:        map[string]string{"DOORSTOP_ENABLED": "1", "MODE": "user", "EXTRA": "1"},
+			},
+		},
+		{
+			name: "user override without strategy keeps the launch mode default",
+			config: &internal.Config{LaunchMode: LaunchModeDirect, GameLaunch: map[string]internal.LaunchOptions{
+				"123": {Executable: `C:\Games\Test\Test.exe`},
+			}},
+			want: internal.LaunchOptions{Strategy: internal.LaunchDirect, Executable: `C:\Games\Test\Test.exe`, Env: map[string]string{}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := internal.Game{ID: "123", Name: "Test Game", Launch: tt.launch}
+			got := GetGameLaunch(tt.config, game)
+			if got.Strategy != tt.want.Strategy || got.Executable != tt.want.Executable || got.Command != tt.want.Command || !maps.Equal(got.Env, tt.want.Env) {
+				t.Errorf("GetGameLaunch() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetGameLaunchDoesNotModifyManifest(t *testing.T) {
+	game := internal.Game{ID: "123", Launch: internal.LaunchOptions{Env: map[string]string{"MODE": "manifest"}}}
+	cfg := &internal.Config{GameLaunch: map[string]internal.LaunchOptions{
+		"123": {Env: map[string]string{"MODE": "user"}},
+	}}
+
+	GetGameLaunch(cfg, game)
+	if game.Launch.Env["MODE"] != "manifest" {
+		t.Errorf("manifest env changed to %q", game.Launch.Env["MODE"])
+	}
+}
+
+func TestCheckLaunchOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		options internal.LaunchOptions
+		wantErr bool
+	}{
+		{name: "empty", options: internal.LaunchOptions{}},
+		{name: "valid", options: internal.LaunchOptions{Strategy: internal.LaunchCommand, Env: map[string]string{"WINEDLLOVERRIDES": "winhttp=n,b"}}},
+		{name: "unknown strategy", options: internal.LaunchOptions{Strategy: "proton"}, wantErr: true},
+		{name: "empty env name", options: internal.LaunchOptions{Env: map[string]string{"": "1"}}, wantErr: true},
+		{name: "env name with equals", options: internal.LaunchOptions{Env: map[string]string{"A=B": "1"}}, wantErr: true},
+		{name: "env name with space", options: internal.LaunchOptions{Env: map[string]string{"MY VAR": "1"}}, wantErr: true},
+		{name: "env name with tab", options: internal.LaunchOptions{Env: map[string]string{"MY\tVAR": "1"}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if message := CheckLaunchOptions(tt.options); (message != "") != tt.wantErr {
+				t.Errorf("CheckLaunchOptions() = %q, wantErr %v", message, tt.wantErr)
+			}
+		})
+	}
+}
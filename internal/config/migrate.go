@@ -0,0 +1,110 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ur-wesley/modhelper/internal"
+	"github.com/ur-wesley/modhelper/internal/r2modman"
+)
+
+const CurrentVersion = 2
+
+var migrations = []func(c *internal.Config, dataDir string){
+	migrateToV1,
+	migrateToV2,
+}
+
+func migrate(c *internal.Config, dataDir string) bool {
+	if c.Version > CurrentVersion {
+		logger().Warn("Config was written by a newer version", "version", c.Version, "supported", CurrentVersion)
+		return false
+	}
+	if c.Version < 0 {
+		logger().Warn("Config has an invalid version, migrating from scratch", "version", c.Version)
+		c.Version = 0
+	}
+
+	from := c.Version
+	for c.Version < CurrentVersion {
+		migrations[c.Version](c, dataDir)
+		c.Version++
+	}
+
+	if c.Version == from {
+		return false
+	}
+	logger().Info("Migrated config", "from", from, "to", c.Version)
+	return true
+}
+
+func migrateToV1(c *internal.Config, dataDir string) {
+	if strings.TrimSpace(c.ManifestURL) == "" {
+		c.ManifestURL = DefaultManifestURL
+	}
+	if strings.TrimSpace(c.TargetDir) == "" {
+		c.TargetDir = dataDir
+	}
+	if strings.TrimSpace(c.ThunderstoreURL) == "" {
+		c.ThunderstoreURL = ThunderstoreURL
+	}
+	c.UpdateChannel = strings.ToLower(strings.TrimSpace(c.UpdateChannel))
+}
+
+func migrateToV2(c *internal.Config, dataDir string) {
+	r2modman.MoveLegacyFolders(strings.TrimSpace(c.TargetDir))
+}
+
+func migrateLegacy(path string) (*internal.Config, error) {
+	for _, legacy := range legacyPaths(path) {
+		data, err := os.ReadFile(legacy)
+		if err != nil {
+			continue
+		}
+
+		var c internal.Config
+		if err := json.Unmarshal(data, &c); err != nil {
+			logger().Warn("Ignoring unreadable legacy config", "path", legacy, "error", err)
+			continue
+		}
+
+		migrate(&c, GetDefaultProfileDir())
+		if err := writeAtomic(path, &c); err != nil {
+			return &c, fmt.Errorf("failed to move %s to %s: %w", legacy, path, err)
+		}
+		if err := os.Rename(legacy, legacy+MigratedSuffix); err != nil {
+			logger().Warn("Could not rename legacy config", "path", legacy, "error", err)
+		}
+
+		logger().Info("Moved legacy config", "from", legacy, "to", path)
+		return &c, Validate(&c)
+	}
+
+	c := Default()
+	migrateToV2(c, c.TargetDir)
+	return c, nil
+}
+
+func legacyPaths(path string) []string {
+	var candidates []string
+	if wd, err := os.Getwd(); err == nil {
+		candidates = append(candidates, filepath.Join(wd, ConfigFileName))
+	}
+	if exe, err := os.Executable(); err == nil {
+		candidates = append(candidates, filepath.Join(filepath.Dir(exe), ConfigFileName))
+	}
+
+	var paths []string
+	seen := map[string]bool{path: true}
+	for _, candidate := range candidates {
+		if seen[candidate] {
+			continue
+		}
+		seen[candidate] = true
+		paths = append(paths, candidate)
+	}
+	return paths
+}
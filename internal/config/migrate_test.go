@@ -0,0 +1,54 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ur-wesley/modhelper/internal"
+)
+
+func TestMigrate(t *testing.T) {
+	tests := []struct {
+		name        string
+		version     int
+		wantVersion int
+		wantChanged bool
+	}{
+		{name: "unversioned", version: 0, wantVersion: CurrentVersion, wantChanged: true},
+		{name: "current", version: CurrentVersion, wantVersion: CurrentVersion},
+		{name: "newer", version: CurrentVersion + 1, wantVersion: CurrentVersion + 1},
+		{name: "negative", version: -3, wantVersion: CurrentVersion, wantChanged: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dataDir := t.TempDir()
+			legacy := filepath.Join(dataDir, "R.E.P.O", "profiles")
+			if err := os.MkdirAll(legacy, 0755); err != nil {
+				t.Fatal(err)
+			}
+
+			c := &internal.Config{Version: tt.version, UpdateChannel: " Beta "}
+			if changed := migrate(c, dataDir); changed != tt.wantChanged {
+				t.Errorf("migrate() = %v, want %v", changed, tt.wantChanged)
+			}
+			if c.Version != tt.wantVersion {
+				t.Errorf("version = %d, want %d", c.Version, tt.wantVersion)
+			}
+			if !tt.wantChanged {
+				return
+			}
+
+			if c.ManifestURL != DefaultManifestURL {
+				t.Errorf("manifest URL = %q, want default", c.ManifestURL)
+			}
+			if c.TargetDir != dataDir {
+				t.Errorf("target folder = %q, want %q", c.TargetDir, dataDir)
+			}
+			if _, err := os.Stat(filepath.Join(dataDir, "REPO", "profiles")); err != nil {
+				t.Errorf("legacy game folder was not moved: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,166 @@
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/ur-wesley/modhelper/internal"
+)
+
+type SettingKind int
+
+const (
+	KindText SettingKind = iota
+	KindURL
+	KindPath
+	KindNumber
+	KindChoice
+)
+
+const (
+	GroupGeneral = "general"
+	GroupNetwork = "network"
+	GroupUpdates = "updates"
+	GroupLaunch  = "launch"
+)
+
+var SettingGroups = []string{GroupGeneral, GroupNetwork, GroupUpdates, GroupLaunch}
+
+type Setting struct {
+	Key      string
+	Group    string
+	Kind     SettingKind
+	Required bool
+	Default  string
+	Choices  []string
+	Min      int
+	Max      int
+
+	Get func(c *internal.Config) string
+	Set func(c *internal.Config, value string) error
+}
+
+var Settings = []Setting{
+	textSetting("manifest_url", GroupGeneral, KindURL, true, DefaultManifestURL, func(c *internal.Config) *string { return &c.ManifestURL }),
+	textSetting("target_dir", GroupGeneral, KindPath, false, "", func(c *internal.Config) *string { return &c.TargetDir }),
+	choiceSetting("language", GroupGeneral, "", languageChoices(), func(c *internal.Config) *string { return &c.Language }),
+	intSetting("manifest_refresh_seconds", GroupGeneral, 10, 3600, DefaultManifestRefreshSeconds, func(c *internal.Config) *int { return &c.ManifestRefreshSeconds }),
+
+	textSetting("thunderstore_url", GroupNetwork, KindURL, false, ThunderstoreURL, func(c *internal.Config) *string { return &c.ThunderstoreURL }),
+	intSetting("http_timeout_seconds", GroupNetwork, 5, 600, DefaultHTTPTimeoutSeconds, func(c *internal.Config) *int { return &c.HTTPTimeoutSeconds }),
+	intSetting("retry_attempts", GroupNetwork, 1, 10, DefaultRetryAttempts, func(c *internal.Config) *int { return &c.RetryAttempts }),
+
+	choiceSetting("update_channel", GroupUpdates, UpdateChannelStable, []string{UpdateChannelStable, UpdateChannelBeta}, func(c *internal.Config) *string { return &c.UpdateChannel }),
+	textSetting("update_feed_url", GroupUpdates, KindURL, false, UpdateFeedURL, func(c *internal.Config) *string { return &c.UpdateFeedURL }),
+	intSetting("update_check_hours", GroupUpdates, 1, 168, DefaultUpdateCheckHours, func(c *internal.Config) *int { return &c.UpdateCheckHours }),
+	intSetting("download_limit_kbps", GroupUpdates, 0, 1000000, 0, func(c *internal.Config) *int { return &c.DownloadLimitKBps }),
+
+	choiceSetting("launch_mode", GroupLaunch, LaunchModeSteam, []string{LaunchModeSteam, LaunchModeDirect}, func(c *internal.Config) *string { return &c.LaunchMode }),
+}
+
+func (s Setting) Check(value string) string {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		if s.Required {
+			return "is required"
+		}
+		return ""
+	}
+
+	switch s.Kind {
+	case KindURL:
+		if !isHTTPURL(value) {
+			return "must be an http(s) URL"
+		}
+	case KindPath:
+		if !filepath.IsAbs(value) {
+			return "must be an absolute path"
+		}
+	case KindNumber:
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return "must be a whole number"
+		}
+		if n != 0 && (n < s.Min || n > s.Max) {
+			return fmt.Sprintf("must be between %d and %d", s.Min, s.Max)
+		}
+	case KindChoice:
+		for _, choice := range s.Choices {
+			if strings.EqualFold(choice, value) {
+				return ""
+			}
+		}
+		return fmt.Sprintf("must be one of %s", strings.Join(s.Choices, ", "))
+	}
+	return ""
+}
+
+func textSetting(key, group string, kind SettingKind, required bool, defaultValue string, field func(*internal.Config) *string) Setting {
+	return Setting{
+		Key:      key,
+		Group:    group,
+		Kind:     kind,
+		Required: required,
+		Default:  defaultValue,
+		Get:      func(c *internal.Config) string { return *field(c) },
+		Set: func(c *internal.Config, value string) error {
+			*field(c) = strings.TrimSpace(value)
+			return nil
+		},
+	}
+}
+
+func intSetting(key, group string, min, max, defaultValue int, field func(*internal.Config) *int) Setting {
+	return Setting{
+		Key:     key,
+		Group:   group,
+		Kind:    KindNumber,
+		Default: strconv.Itoa(defaultValue),
+		Min:     min,
+		Max:     max,
+		Get: func(c *internal.Config) string {
+			if *field(c) == 0 {
+				return ""
+			}
+			return strconv.Itoa(*field(c))
+		},
+		Set: func(c *internal.Config, value string) error {
+			value = strings.TrimSpace(value)
+			if value == "" {
+				*field(c) = 0
+				return nil
+			}
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return FieldError{Field: key, Message: "must be a whole number"}
+			}
+			*field(c) = n
+			return nil
+		},
+	}
+}
+
+func choiceSetting(key, group, defaultValue string, choices []string, field func(*internal.Config) *string) Setting {
+	return Setting{
+		Key:     key,
+		Group:   group,
+		Kind:    KindChoice,
+		Default: defaultValue,
+		Choices: choices,
+		Get:     func(c *internal.Config) string { return *field(c) },
+		Set: func(c *internal.Config, value string) error {
+			*field(c) = strings.TrimSpace(value)
+			return nil
+		},
+	}
+}
+
+func languageChoices() []string {
+	choices := make([]string, len(internal.Languages))
+	for i, language := range internal.Languages {
+		choices[i] = language.Code
+	}
+	return choices
+}
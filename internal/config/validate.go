@@ -0,0 +1,70 @@
+package config
+
+import (
+	"fmt"
+	"maps"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/ur-wesley/modhelper/internal"
+)
+
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+func (e FieldError) Error() string {
+	return e.Field + ": " + e.Message
+}
+
+type ValidationError []FieldError
+
+func (e ValidationError) Error() string {
+	problems := make([]string, len(e))
+	for i, fieldErr := range e {
+		problems[i] = fieldErr.Error()
+	}
+	return "invalid config: " + strings.Join(problems, "; ")
+}
+
+func Validate(c *internal.Config) error {
+	var errs ValidationError
+	for _, setting := range Settings {
+		if message := setting.Check(setting.Get(c)); message != "" {
+			errs = append(errs, FieldError{Field: setting.Key, Message: message})
+		}
+	}
+
+	for _, id := range slices.Sorted(maps.Keys(c.GameLaunch)) {
+		if message := CheckLaunchOptions(c.GameLaunch[id]); message != "" {
+			errs = append(errs, FieldError{Field: "game_launch." + id, Message: message})
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func CheckLaunchOptions(options internal.LaunchOptions) string {
+	if options.Strategy != "" && !slices.Contains(internal.LaunchStrategies, options.Strategy) {
+		return fmt.Sprintf("strategy must be one of %s", strings.Join(internal.LaunchStrategies, ", "))
+	}
+	for key := range options.Env {
+		if !internal.ValidEnvName(key) {
+			return fmt.Sprintf("invalid environment variable name %q", key)
+		}
+	}
+	return ""
+}
+
+func isHTTPURL(raw string) bool {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return false
+	}
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
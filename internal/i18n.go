@@ -0,0 +1,82 @@
+package internal
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+const (
+	LanguageGerman  = "de"
+	LanguageEnglish = "en"
+)
+
+type Language struct {
+	Code string
+	Name string
+}
+
+var Languages = []Language{
+	{Code: LanguageGerman, Name: "Deutsch"},
+	{Code: LanguageEnglish, Name: "English"},
+}
+
+type Plural struct {
+	One   string
+	Other string
+}
+
+func (p Plural) Format(n int, args ...any) string {
+	format := p.Other
+	if n == 1 {
+		format = p.One
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+func MessagesFor(language string) Messages {
+	switch language {
+	case LanguageGerman:
+		return German()
+	default:
+		return English()
+	}
+}
+
+func ResolveLanguage(configured, systemLocale string) string {
+	for _, candidate := range []string{configured, systemLocale} {
+		code := strings.ToLower(strings.TrimSpace(candidate))
+		if i := strings.IndexAny(code, "-_."); i >= 0 {
+			code = code[:i]
+		}
+		for _, language := range Languages {
+			if language.Code == code {
+				return code
+			}
+		}
+	}
+	return LanguageEnglish
+}
+
+func MissingMessages(m Messages) []string {
+	var missing []string
+
+	value := reflect.ValueOf(m)
+	for i := 0; i < value.NumField(); i++ {
+		name := value.Type().Field(i).Name
+		switch field := value.Field(i).Interface().(type) {
+		case string:
+			if strings.TrimSpace(field) == "" {
+				missing = append(missing, name)
+			}
+		case Plural:
+			if strings.TrimSpace(field.One) == "" {
+				missing = append(missing, name+".One")
+			}
+			if strings.TrimSpace(field.Other) == "" {
+				missing = append(missing, name+".Other")
+			}
+		}
+	}
+	return missing
+}
@@ -0,0 +1,32 @@
+package internal
+
+import "testing"
+
+func TestCatalogsAreComplete(t *testing.T) {
+	for _, language := range Languages {
+		t.Run(language.Code, func(t *testing.T) {
+			if missing := MissingMessages(MessagesFor(language.Code)); len(missing) > 0 {
+				t.Errorf("missing messages: %v", missing)
+			}
+		})
+	}
+}
+
+func TestResolveLanguage(t *testing.T) {
+	tests := []struct {
+		configured string
+		system     string
+		want       string
+	}{
+		{"", "de_DE.UTF-8", LanguageGerman},
+		{"", "fr-FR", LanguageEnglish},
+		{"en", "de-DE", LanguageEnglish},
+		{"DE", "", LanguageGerman},
+	}
+
+	for _, tt := range tests {
+		if got := ResolveLanguage(tt.configured, tt.system); got != tt.want {
+			t.Errorf("ResolveLanguage(%q, %q) = %q, want %q", tt.configured, tt.system, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package logging
+
+import (
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	FileName = "modhelper.log"
+	LevelEnv = "MODHELPER_LOG_LEVEL"
+
+	maxFileSize = 5 << 20
+	maxBackups  = 4
+)
+
+func Dir() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "modhelper", "logs"), nil
+}
+
+func Setup(dev bool) (io.Closer, error) {
+	level := slog.LevelInfo
+	if dev {
+		level = slog.LevelDebug
+	}
+	if configured, ok := parseLevel(os.Getenv(LevelEnv)); ok {
+		level = configured
+	}
+
+	options := &slog.HandlerOptions{Level: level, AddSource: dev}
+
+	dir, err := Dir()
+	if err == nil {
+		err = os.MkdirAll(dir, 0755)
+	}
+	var file *RotatingWriter
+	if err == nil {
+		file, err = OpenRotating(filepath.Join(dir, FileName), maxFileSize, maxBackups)
+	}
+	if err != nil {
+		slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, options)))
+		return nopCloser{}, err
+	}
+
+	var output io.Writer = file
+	if dev {
+		output = io.MultiWriter(os.Stderr, file)
+	}
+
+	slog.SetDefault(slog.New(slog.NewTextHandler(output, options)))
+	return file, nil
+}
+
+func For(component string) *slog.Logger {
+	return slog.Default().With("component", component)
+}
+
+func parseLevel(value string) (slog.Level, bool) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "debug":
+		return slog.LevelDebug, true
+	case "info":
+		return slog.LevelInfo, true
+	case "warn", "warning":
+		return slog.LevelWarn, true
+	case "error":
+		return slog.LevelError, true
+	}
+	return 0, false
+}
+
+type nopCloser struct{}
+
+func (nopCloser) Close() error {
+	return nil
+}
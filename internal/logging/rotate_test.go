@@ -0,0 +1,75 @@
+package logging
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRotatingWriter(t *testing.T) {
+	const (
+		maxSize = 100
+		backups = 2
+	)
+
+	path := filepath.Join(t.TempDir(), FileName)
+	w, err := OpenRotating(path, maxSize, backups)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+
+	line := []byte(strings.Repeat("x", 29) + "\n")
+	for i := 0; i < 20; i++ {
+		if _, err := w.Write(line); err != nil {
+			t.Fatalf("Write() #%d = %v", i, err)
+		}
+	}
+
+	for i := 0; i <= backups; i++ {
+		name := path
+		if i > 0 {
+			name = backupName(path, i)
+		}
+		info, err := os.Stat(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info.Size() > maxSize {
+			t.Errorf("%s is %d bytes, limit %d", filepath.Base(name), info.Size(), maxSize)
+		}
+	}
+	if _, err := os.Stat(backupName(path, backups+1)); !os.IsNotExist(err) {
+		t.Errorf("more than %d backups kept: %v", backups, err)
+	}
+}
+
+func TestRotatingWriterKeepsWritingWhenRotationFails(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+	w, err := OpenRotating(path, 10, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+
+	blocked := backupName(path, 1)
+	if err := os.MkdirAll(filepath.Join(blocked, "locked"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, line := range []string{"first line\n", "second line\n", "third line\n"} {
+		if _, err := w.Write([]byte(line)); err != nil {
+			t.Fatalf("Write(%q) = %v", line, err)
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(data, []byte("first line\nsecond line\nthird line\n")) {
+		t.Errorf("log = %q, want all lines appended", data)
+	}
+}
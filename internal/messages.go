@@ -18,6 +18,92 @@ type Messages struct {
 	UpdateProfile   string
 	Updating        string
 
+	DowngradeProfile string
+	ReinstallProfile string
+	CheckingChanges  string
+
+	ProfileChangesTitle string
+	Changelog           string
+	ModsAdded           string
+	ModsRemoved         string
+	ModsUpgraded        string
+	ModsDowngraded      string
+	ConfigAdded         string
+	ConfigRemoved       string
+	ConfigChanged       string
+	NoProfileChanges    string
+	UserModifiedConfigs string
+
+	ConfigPolicy       string
+	PolicyMerge        string
+	PolicyKeepUser     string
+	PolicyTakeNew      string
+	ConfigSummaryTitle string
+	ConfigKept         string
+	ConfigReplaced     string
+	ConfigMerged       string
+	ConfigRestored     string
+	ConfigYourValue    string
+	ConfigProfileValue string
+
+	ConfigEditorTitle    string
+	ConfigFile           string
+	ProfileDefault       string
+	ConfigSaved          string
+	NoConfigFiles        string
+	DiscardConfigChanges string
+
+	LaunchOptionsTitle   string
+	LaunchStrategy       string
+	LaunchDefault        string
+	LaunchSteamURL       string
+	LaunchAppLaunch      string
+	LaunchDirectExe      string
+	LaunchCustomCommand  string
+	LaunchExecutable     string
+	LaunchCommand        string
+	LaunchEnv            string
+	HelpLaunchExecutable string
+	HelpLaunchCommand    string
+	HelpLaunchEnv        string
+	InvalidEnvLine       string
+
+	ImportCode        string
+	ImportCodeTitle   string
+	ProfileCode       string
+	ImportGame        string
+	ImportProfileName string
+	ImportingCode     string
+	ImportFailed      string
+	ImportSuccess     string
+
+	HealthTitle          string
+	CheckingHealth       string
+	HealthOK             Plural
+	HealthIssues         Plural
+	HealthDeprecated     string
+	HealthVersionRemoved string
+	HealthNSFW           string
+	HealthMissing        string
+	HealthCheckFailed    string
+	HealthRecheck        string
+
+	VerifyProfile           string
+	RepairProfile           string
+	Verifying               string
+	Repairing               string
+	VerifyOK                Plural
+	VerifyProblems          Plural
+	VerifyNotIndexed        string
+	RepairQuestion          string
+	RepairDone              string
+	RepairFailed            string
+	ProblemMissing          string
+	ProblemCorrupt          string
+	ProblemConfigMissing    string
+	ProblemEssentialMissing string
+	ProblemModMissing       string
+
 	Download  string
 	Install   string
 	Launch    string
@@ -32,20 +118,65 @@ type Messages struct {
 	LaunchFailed       string
 	StopFailed         string
 	UpdateFailed       string
+	InvalidEntry       string
+	InvalidEntryTitle  string
+	SelectVariant      string
 
+	Status         string
 	R2ModmanStatus string
 	SteamStatus    string
 	ManifestStatus string
 
-	ManifestURL string
-	TargetDir   string
-	Save        string
-	Cancel      string
+	ManifestURL        string
+	TargetDir          string
+	ThunderstoreURL    string
+	UpdateChannel      string
+	UpdateFeedURL      string
+	DownloadLimit      string
+	Language           string
+	LanguageSystem     string
+	Save               string
+	Cancel             string
+	ConfigSavedTitle   string
+	ConfigSavedMessage string
+	ConfigInvalid      string
+	AdminInfo          string
+
+	HTTPTimeout         string
+	RetryAttempts       string
+	ManifestRefresh     string
+	UpdateCheckInterval string
+	LaunchMode          string
+	LaunchModeSteam     string
+	LaunchModeDirect    string
+
+	SettingsGeneral string
+	SettingsNetwork string
+	SettingsUpdates string
+	SettingsLaunch  string
+
+	HelpManifestURL         string
+	HelpTargetDir           string
+	HelpLanguage            string
+	HelpManifestRefresh     string
+	HelpThunderstoreURL     string
+	HelpHTTPTimeout         string
+	HelpRetryAttempts       string
+	HelpUpdateChannel       string
+	HelpUpdateFeedURL       string
+	HelpUpdateCheckInterval string
+	HelpDownloadLimit       string
+	HelpLaunchMode          string
 
 	InfoTitle   string
 	InfoContent string
 	Close       string
 
+	SupportBundle         string
+	CreatingSupportBundle string
+	SupportBundleCreated  string
+	SupportBundleFailed   string
+
 	Error            string
 	SteamNotFound    string
 	R2ModmanNotFound string
@@ -55,10 +186,19 @@ type Messages struct {
 	UpdateButton        string
 	UpdateDownloading   string
 	UpdateInstalling    string
+	UpdateVerifying     string
+	UpdateCancelled     string
+	UpdateChannelStable string
+	UpdateChannelBeta   string
 	UpdateError         string
 	UpdateSuccess       string
 	UpdateRestart       string
 	CheckingUpdates     string
+	CheckForUpdates     string
+	UpdateCheckFailed   string
+	NewVersionAvailable string
+	AlreadyLatest       string
+	UpdateDetails       string
 	NoUpdatesAvailable  string
 	UpdateDialogTitle   string
 	UpdateDialogMessage string
@@ -85,6 +225,92 @@ func German() Messages {
 		UpdateProfile:   "Profil aktualisieren",
 		Updating:        "Aktualisiere...",
 
+		DowngradeProfile: "Profil zurücksetzen",
+		ReinstallProfile: "Neu installieren",
+		CheckingChanges:  "Prüfe Änderungen...",
+
+		ProfileChangesTitle: "Änderungen im Profil",
+		Changelog:           "Changelog",
+		ModsAdded:           "Neue Mods",
+		ModsRemoved:         "Entfernte Mods",
+		ModsUpgraded:        "Aktualisierte Mods",
+		ModsDowngraded:      "Zurückgestufte Mods",
+		ConfigAdded:         "Neue Konfigurationsdateien",
+		ConfigRemoved:       "Entfernte Konfigurationsdateien",
+		ConfigChanged:       "Geänderte Konfigurationsdateien",
+		NoProfileChanges:    "Keine Änderungen an Mods oder Konfiguration.",
+		UserModifiedConfigs: "Von dir geänderte Dateien",
+
+		ConfigPolicy:       "Eigene Einstellungen:",
+		PolicyMerge:        "Zusammenführen",
+		PolicyKeepUser:     "Meine behalten",
+		PolicyTakeNew:      "Profil übernehmen",
+		ConfigSummaryTitle: "Konfiguration übernommen",
+		ConfigKept:         "deine Version behalten",
+		ConfigReplaced:     "durch Profil ersetzt",
+		ConfigMerged:       "zusammengeführt",
+		ConfigRestored:     "wiederhergestellt",
+		ConfigYourValue:    "dein Wert",
+		ConfigProfileValue: "Profil",
+
+		ConfigEditorTitle:    "Konfiguration bearbeiten",
+		ConfigFile:           "Datei:",
+		ProfileDefault:       "Profilstandard",
+		ConfigSaved:          "Die Konfiguration wurde gespeichert.",
+		NoConfigFiles:        "Dieses Profil enthält keine Konfigurationsdateien.",
+		DiscardConfigChanges: "Ungespeicherte Änderungen verwerfen?",
+
+		LaunchOptionsTitle:   "Startoptionen: %s",
+		LaunchStrategy:       "Startart:",
+		LaunchDefault:        "Standard (%s)",
+		LaunchSteamURL:       "Steam-URL",
+		LaunchAppLaunch:      "Steam (-applaunch)",
+		LaunchDirectExe:      "Spieldatei direkt",
+		LaunchCustomCommand:  "Eigener Befehl",
+		LaunchExecutable:     "Spieldatei:",
+		LaunchCommand:        "Befehl:",
+		LaunchEnv:            "Umgebungsvariablen:",
+		HelpLaunchExecutable: "Pfad zur .exe, absolut oder relativ zum Spielordner. Leer lassen für automatische Suche.",
+		HelpLaunchCommand:    "Platzhalter: ${exe}, ${gameDir}, ${appId}, ${args}, ${profileLoc}, ${profileName}",
+		HelpLaunchEnv:        "Eine Variable pro Zeile als NAME=Wert, z. B. WINEDLLOVERRIDES=winhttp=n,b",
+		InvalidEnvLine:       "Ungültige Zeile, erwartet wird NAME=Wert: %s",
+
+		ImportCode:        "Profilcode importieren",
+		ImportCodeTitle:   "Profil aus Code importieren",
+		ProfileCode:       "Profilcode:",
+		ImportGame:        "Spiel:",
+		ImportProfileName: "Profilname:",
+		ImportingCode:     "Importiere Profil...",
+		ImportFailed:      "Import fehlgeschlagen",
+		ImportSuccess:     "Profil '%s' wurde importiert.",
+
+		HealthTitle:          "Paketzustand",
+		CheckingHealth:       "Prüfe Pakete auf Thunderstore...",
+		HealthOK:             Plural{One: "%d Paket ist in Ordnung.", Other: "Alle %d Pakete sind in Ordnung."},
+		HealthIssues:         Plural{One: "%d von %d Paketen hat Hinweise.", Other: "%d von %d Paketen haben Hinweise."},
+		HealthDeprecated:     "veraltet",
+		HealthVersionRemoved: "Version entfernt",
+		HealthNSFW:           "NSFW",
+		HealthMissing:        "nicht mehr in der Community",
+		HealthCheckFailed:    "Prüfung fehlgeschlagen",
+		HealthRecheck:        "Erneut prüfen",
+
+		VerifyProfile:           "Profil prüfen",
+		RepairProfile:           "Profil reparieren",
+		Verifying:               "Prüfe Profildateien...",
+		Repairing:               "Repariere Profil...",
+		VerifyOK:                Plural{One: "%d Datei ist in Ordnung.", Other: "Alle %d Dateien sind in Ordnung."},
+		VerifyProblems:          Plural{One: "%d Problem gefunden:", Other: "%d Probleme gefunden:"},
+		VerifyNotIndexed:        "Für dieses Profil wurden bei der Installation keine Dateien aufgezeichnet. Es wurden nur die Loader-Dateien geprüft; installiere das Profil neu, um alles prüfen zu können.",
+		RepairQuestion:          "Fehlende oder beschädigte Dateien jetzt neu herunterladen?",
+		RepairDone:              "Das Profil wurde repariert.",
+		RepairFailed:            "Reparatur fehlgeschlagen",
+		ProblemMissing:          "fehlt",
+		ProblemCorrupt:          "beschädigt",
+		ProblemConfigMissing:    "Konfiguration fehlt",
+		ProblemEssentialMissing: "Loader-Datei fehlt",
+		ProblemModMissing:       "Mod nicht installiert",
+
 		Download:  "Herunterladen",
 		Install:   "Installieren",
 		Launch:    "Starten",
@@ -99,15 +325,55 @@ func German() Messages {
 		LaunchFailed:       "Start fehlgeschlagen",
 		StopFailed:         "Beenden fehlgeschlagen",
 		UpdateFailed:       "Aktualisierung fehlgeschlagen",
+		InvalidEntry:       "Ungültiger Eintrag",
+		InvalidEntryTitle:  "Fehler im Manifest",
+		SelectVariant:      "Variante wählen",
 
+		Status:         "Status:",
 		R2ModmanStatus: "r2modman",
 		SteamStatus:    "Steam",
 		ManifestStatus: "Manifest",
 
-		ManifestURL: "Manifest-URL:",
-		TargetDir:   "Zielordner:",
-		Save:        "Speichern",
-		Cancel:      "Abbrechen",
+		ManifestURL:        "Manifest-URL:",
+		TargetDir:          "Zielordner:",
+		ThunderstoreURL:    "Thunderstore-URL:",
+		UpdateChannel:      "Update-Kanal:",
+		UpdateFeedURL:      "Update-Feed-URL:",
+		DownloadLimit:      "Download-Limit (KB/s):",
+		Language:           "Sprache:",
+		LanguageSystem:     "Systemsprache",
+		Save:               "Speichern",
+		Cancel:             "Abbrechen",
+		ConfigSavedTitle:   "✅ Konfiguration gespeichert",
+		ConfigSavedMessage: "Die Einstellungen wurden erfolgreich gespeichert.",
+		ConfigInvalid:      "Die Konfiguration enthält Fehler",
+		AdminInfo:          "Änderungen werden nach dem Speichern beim nächsten Start aktiv. Leere Felder verwenden den angezeigten Standardwert.",
+
+		HTTPTimeout:         "HTTP-Timeout (s):",
+		RetryAttempts:       "Wiederholungsversuche:",
+		ManifestRefresh:     "Manifest-Aktualisierung (s):",
+		UpdateCheckInterval: "Update-Prüfung (h):",
+		LaunchMode:          "Startmodus:",
+		LaunchModeSteam:     "Über Steam (mit Overlay)",
+		LaunchModeDirect:    "Direkt (ohne Steam-Overlay)",
+
+		SettingsGeneral: "Allgemein",
+		SettingsNetwork: "Netzwerk",
+		SettingsUpdates: "Updates",
+		SettingsLaunch:  "Spielstart",
+
+		HelpManifestURL:         "URL zum JSON-Manifest mit der Spieleliste.",
+		HelpTargetDir:           "Datenordner des Mod-Managers, in den Profile installiert werden. Leer lassen für den erkannten Mod-Manager.",
+		HelpLanguage:            "Sprache der Oberfläche.",
+		HelpManifestRefresh:     "Wie oft das Manifest im Hintergrund auf neue Profilversionen geprüft wird.",
+		HelpThunderstoreURL:     "Basis-URL der Thunderstore-API für Mods und Profilcodes.",
+		HelpHTTPTimeout:         "Maximale Dauer einer Anfrage an Thunderstore oder eines Profil-Downloads.",
+		HelpRetryAttempts:       "Wie oft fehlgeschlagene Thunderstore-Anfragen wiederholt werden.",
+		HelpUpdateChannel:       "Stabil oder Beta (Vorabversionen für Tester).",
+		HelpUpdateFeedURL:       "GitHub-Releases-API oder statischer JSON-Feed für App-Updates.",
+		HelpUpdateCheckInterval: "Wie oft im Hintergrund nach App-Updates gesucht wird.",
+		HelpDownloadLimit:       "Maximale Geschwindigkeit für App-Updates in KB/s (0 = unbegrenzt).",
+		HelpLaunchMode:          "Über Steam startet das Spiel mit Overlay, direkt startet die Spieldatei ohne Steam-Client.",
 
 		InfoTitle: "Anleitung",
 		InfoContent: `VERWENDUNG:
@@ -139,6 +405,11 @@ Profile werden automatisch in die
 richtige Verzeichnisstruktur installiert.`,
 		Close: "Schließen",
 
+		SupportBundle:         "Support-Paket erstellen",
+		CreatingSupportBundle: "Sammle Logs und Profildaten...",
+		SupportBundleCreated:  "Das Support-Paket wurde gespeichert:\n%s",
+		SupportBundleFailed:   "Support-Paket konnte nicht erstellt werden",
+
 		Error:            "Fehler",
 		SteamNotFound:    "Steam nicht gefunden",
 		R2ModmanNotFound: "r2modman nicht gefunden",
@@ -148,10 +419,27 @@ richtige Verzeichnisstruktur installiert.`,
 		UpdateButton:        "Aktualisieren",
 		UpdateDownloading:   "Lade Update herunter...",
 		UpdateInstalling:    "Installiere Update...",
+		UpdateVerifying:     "Prüfe Update-Signatur...",
+		UpdateCancelled:     "Der Download wurde abgebrochen. Beim nächsten Versuch wird er fortgesetzt.",
+		UpdateChannelStable: "Stabil",
+		UpdateChannelBeta:   "Beta",
 		UpdateError:         "Update fehlgeschlagen",
 		UpdateSuccess:       "Update erfolgreich",
 		UpdateRestart:       "Anwendung wird neu gestartet...",
 		CheckingUpdates:     "Prüfe Updates...",
+		CheckForUpdates:     "Nach Updates suchen",
+		UpdateCheckFailed:   "Update-Prüfung fehlgeschlagen",
+		NewVersionAvailable: "Neue Version verfügbar: %s",
+		AlreadyLatest:       "Sie verwenden bereits die neueste Version (%s)",
+		UpdateDetails: `**Neue Version verfügbar!**
+
+**Aktuelle Version:** %s
+**Neue Version:** %s
+
+**Änderungen:**
+%s
+
+Möchten Sie jetzt aktualisieren?`,
 		NoUpdatesAvailable:  "Keine Updates verfügbar",
 		UpdateDialogTitle:   "Update verfügbar",
 		UpdateDialogMessage: "Eine neue Version ist verfügbar. Jetzt aktualisieren?",
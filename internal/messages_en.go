@@ -0,0 +1,243 @@
+package internal
+
+func English() Messages {
+	return Messages{
+		WindowTitle: "R2ModMan Profile Sharer",
+		SearchGames: "Search games...",
+		AdminMode:   "Admin mode",
+
+		NotInstalled:    "Not installed",
+		InstallAndPlay:  "Install & play",
+		PlayWithProfile: "Play with profile",
+		PlayGame:        "Start game",
+		Installing:      "Installing...",
+		GameRunning:     "Running",
+		GameActive:      "Active",
+		RunningButton:   "Running...",
+		StopGame:        "Stop game",
+		Stopping:        "Stopping...",
+		UpdateProfile:   "Update profile",
+		Updating:        "Updating...",
+
+		DowngradeProfile: "Roll back profile",
+		ReinstallProfile: "Reinstall",
+		CheckingChanges:  "Checking changes...",
+
+		ProfileChangesTitle: "Profile changes",
+		Changelog:           "Changelog",
+		ModsAdded:           "New mods",
+		ModsRemoved:         "Removed mods",
+		ModsUpgraded:        "Updated mods",
+		ModsDowngraded:      "Downgraded mods",
+		ConfigAdded:         "New config files",
+		ConfigRemoved:       "Removed config files",
+		ConfigChanged:       "Changed config files",
+		NoProfileChanges:    "No changes to mods or configuration.",
+		UserModifiedConfigs: "Files you changed",
+
+		ConfigPolicy:       "Your settings:",
+		PolicyMerge:        "Merge",
+		PolicyKeepUser:     "Keep mine",
+		PolicyTakeNew:      "Use profile",
+		ConfigSummaryTitle: "Configuration applied",
+		ConfigKept:         "kept your version",
+		ConfigReplaced:     "replaced by profile",
+		ConfigMerged:       "merged",
+		ConfigRestored:     "restored",
+		ConfigYourValue:    "your value",
+		ConfigProfileValue: "profile",
+
+		ConfigEditorTitle:    "Edit configuration",
+		ConfigFile:           "File:",
+		ProfileDefault:       "Profile default",
+		ConfigSaved:          "The configuration has been saved.",
+		NoConfigFiles:        "This profile has no config files.",
+		DiscardConfigChanges: "Discard unsaved changes?",
+
+		LaunchOptionsTitle:   "Launch options: %s",
+		LaunchStrategy:       "Launch via:",
+		LaunchDefault:        "Default (%s)",
+		LaunchSteamURL:       "Steam URL",
+		LaunchAppLaunch:      "Steam (-applaunch)",
+		LaunchDirectExe:      "Game executable",
+		LaunchCustomCommand:  "Custom command",
+		LaunchExecutable:     "Executable:",
+		LaunchCommand:        "Command:",
+		LaunchEnv:            "Environment variables:",
+		HelpLaunchExecutable: "Path to the .exe, absolute or relative to the game folder. Leave empty to detect it.",
+		HelpLaunchCommand:    "Placeholders: ${exe}, ${gameDir}, ${appId}, ${args}, ${profileLoc}, ${profileName}",
+		HelpLaunchEnv:        "One variable per line as NAME=value, e.g. WINEDLLOVERRIDES=winhttp=n,b",
+		InvalidEnvLine:       "Invalid line, expected NAME=value: %s",
+
+		ImportCode:        "Import profile code",
+		ImportCodeTitle:   "Import profile from code",
+		ProfileCode:       "Profile code:",
+		ImportGame:        "Game:",
+		ImportProfileName: "Profile name:",
+		ImportingCode:     "Importing profile...",
+		ImportFailed:      "Import failed",
+		ImportSuccess:     "Profile '%s' has been imported.",
+
+		HealthTitle:          "Package health",
+		CheckingHealth:       "Checking packages on Thunderstore...",
+		HealthOK:             Plural{One: "%d package is fine.", Other: "All %d packages are fine."},
+		HealthIssues:         Plural{One: "%d of %d packages has warnings.", Other: "%d of %d packages have warnings."},
+		HealthDeprecated:     "deprecated",
+		HealthVersionRemoved: "version removed",
+		HealthNSFW:           "NSFW",
+		HealthMissing:        "no longer in the community",
+		HealthCheckFailed:    "Check failed",
+		HealthRecheck:        "Check again",
+
+		VerifyProfile:           "Verify profile",
+		RepairProfile:           "Repair profile",
+		Verifying:               "Verifying profile files...",
+		Repairing:               "Repairing profile...",
+		VerifyOK:                Plural{One: "%d file is fine.", Other: "All %d files are fine."},
+		VerifyProblems:          Plural{One: "%d problem found:", Other: "%d problems found:"},
+		VerifyNotIndexed:        "No installed files were recorded for this profile. Only the loader files were checked; reinstall the profile to enable a full check.",
+		RepairQuestion:          "Download missing or damaged files again now?",
+		RepairDone:              "The profile has been repaired.",
+		RepairFailed:            "Repair failed",
+		ProblemMissing:          "missing",
+		ProblemCorrupt:          "damaged",
+		ProblemConfigMissing:    "config missing",
+		ProblemEssentialMissing: "loader file missing",
+		ProblemModMissing:       "mod not installed",
+
+		Download:  "Download",
+		Install:   "Install",
+		Launch:    "Launch",
+		Configure: "Configure",
+		Stop:      "Stop",
+		Update:    "Update",
+
+		LoadingGames:       "Loading games...",
+		NoGamesFound:       "No games found",
+		ProfileInstalled:   "Profile installed successfully",
+		InstallationFailed: "Installation failed",
+		LaunchFailed:       "Launch failed",
+		StopFailed:         "Stopping failed",
+		UpdateFailed:       "Update failed",
+		InvalidEntry:       "Invalid entry",
+		InvalidEntryTitle:  "Manifest error",
+		SelectVariant:      "Select variant",
+
+		Status:         "Status:",
+		R2ModmanStatus: "r2modman",
+		SteamStatus:    "Steam",
+		ManifestStatus: "Manifest",
+
+		ManifestURL:        "Manifest URL:",
+		TargetDir:          "Target folder:",
+		ThunderstoreURL:    "Thunderstore URL:",
+		UpdateChannel:      "Update channel:",
+		UpdateFeedURL:      "Update feed URL:",
+		DownloadLimit:      "Download limit (KB/s):",
+		Language:           "Language:",
+		LanguageSystem:     "System language",
+		Save:               "Save",
+		Cancel:             "Cancel",
+		ConfigSavedTitle:   "✅ Configuration saved",
+		ConfigSavedMessage: "The settings have been saved.",
+		ConfigInvalid:      "The configuration has problems",
+		AdminInfo:          "Changes take effect on the next start after saving. Empty fields use the default shown.",
+
+		HTTPTimeout:         "HTTP timeout (s):",
+		RetryAttempts:       "Retry attempts:",
+		ManifestRefresh:     "Manifest refresh (s):",
+		UpdateCheckInterval: "Update check (h):",
+		LaunchMode:          "Launch mode:",
+		LaunchModeSteam:     "Through Steam (with overlay)",
+		LaunchModeDirect:    "Directly (without Steam overlay)",
+
+		SettingsGeneral: "General",
+		SettingsNetwork: "Network",
+		SettingsUpdates: "Updates",
+		SettingsLaunch:  "Game launch",
+
+		HelpManifestURL:         "URL of the JSON manifest listing the games.",
+		HelpTargetDir:           "Data folder of the mod manager that profiles are installed into. Leave empty to use the detected mod manager.",
+		HelpLanguage:            "Interface language.",
+		HelpManifestRefresh:     "How often the manifest is checked for new profile versions in the background.",
+		HelpThunderstoreURL:     "Base URL of the Thunderstore API used for mods and profile codes.",
+		HelpHTTPTimeout:         "Maximum duration of a Thunderstore request or profile download.",
+		HelpRetryAttempts:       "How often failed Thunderstore requests are retried.",
+		HelpUpdateChannel:       "Stable or Beta (pre-releases for testers).",
+		HelpUpdateFeedURL:       "GitHub releases API or a static JSON feed for app updates.",
+		HelpUpdateCheckInterval: "How often to check for app updates in the background.",
+		HelpDownloadLimit:       "Maximum speed for app updates in KB/s (0 = unlimited).",
+		HelpLaunchMode:          "Through Steam starts the game with the overlay, directly runs the game executable without the Steam client.",
+
+		InfoTitle: "Guide",
+		InfoContent: `USAGE:
+
+1. FIND A GAME
+   • Type the game name into the search box
+   • Pick it from the list
+
+2. INSTALL THE PROFILE
+   • Click "Install & play"
+   • Wait for the download
+
+3. START THE GAME
+   • Click "Play with profile"
+   • Steam starts automatically
+
+REQUIREMENTS:
+• Steam installed
+• r2modman recommended
+• Internet connection
+
+PROBLEMS:
+• Admin mode for configuration
+• Profiles in the r2modman folder
+• Steam overlay for the best experience
+
+NOTE:
+Profiles are installed into the
+right folder structure automatically.`,
+		Close: "Close",
+
+		SupportBundle:         "Create support bundle",
+		CreatingSupportBundle: "Collecting logs and profile data...",
+		SupportBundleCreated:  "The support bundle has been saved:\n%s",
+		SupportBundleFailed:   "Could not create the support bundle",
+
+		Error:            "Error",
+		SteamNotFound:    "Steam not found",
+		R2ModmanNotFound: "r2modman not found",
+		NetworkError:     "Network error",
+
+		UpdateAvailable:     "Update available",
+		UpdateButton:        "Update",
+		UpdateDownloading:   "Downloading update...",
+		UpdateInstalling:    "Installing update...",
+		UpdateVerifying:     "Verifying update signature...",
+		UpdateCancelled:     "The download was cancelled. It will resume on the next attempt.",
+		UpdateChannelStable: "Stable",
+		UpdateChannelBeta:   "Beta",
+		UpdateError:         "Update failed",
+		UpdateSuccess:       "Update successful",
+		UpdateRestart:       "Restarting application...",
+		CheckingUpdates:     "Checking for updates...",
+		CheckForUpdates:     "Check for updates",
+		UpdateCheckFailed:   "Update check failed",
+		NewVersionAvailable: "New version available: %s",
+		AlreadyLatest:       "You are already using the latest version (%s)",
+		UpdateDetails: `**New version available!**
+
+**Current version:** %s
+**New version:** %s
+
+**Changes:**
+%s
+
+Do you want to update now?`,
+		NoUpdatesAvailable:  "No updates available",
+		UpdateDialogTitle:   "Update available",
+		UpdateDialogMessage: "A new version is available. Update now?",
+		UpdateNow:           "Update now",
+		UpdateLater:         "Later",
+	}
+}
@@ -0,0 +1,252 @@
+package profile
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var utf8BOM = []byte("\xef\xbb\xbf")
+
+type CfgValueKind int
+
+const (
+	CfgString CfgValueKind = iota
+	CfgBool
+	CfgEnum
+	CfgFlags
+	CfgInt
+	CfgFloat
+)
+
+type CfgEntry struct {
+	Section          string
+	Key              string
+	Value            string
+	Description      string
+	Type             string
+	Default          string
+	HasDefault       bool
+	AcceptableValues []string
+	Multiple         bool
+	Min              float64
+	Max              float64
+	HasRange         bool
+
+	line int
+}
+
+type CfgFile struct {
+	Header  []string
+	Entries []*CfgEntry
+
+	bom     bool
+	lines   []string
+	endings []string
+}
+
+func (e *CfgEntry) Kind() CfgValueKind {
+	switch {
+	case strings.EqualFold(e.Type, "Boolean"):
+		return CfgBool
+	case len(e.AcceptableValues) > 0 && e.Multiple:
+		return CfgFlags
+	case len(e.AcceptableValues) > 0:
+		return CfgEnum
+	}
+
+	switch strings.ToLower(e.Type) {
+	case "byte", "sbyte", "int16", "uint16", "int32", "uint32", "int64", "uint64":
+		return CfgInt
+	case "single", "double", "decimal":
+		return CfgFloat
+	}
+	return CfgString
+}
+
+func ParseCfg(data []byte) (*CfgFile, error) {
+	file := &CfgFile{}
+	if bytes.HasPrefix(data, utf8BOM) {
+		file.bom = true
+		data = data[len(utf8BOM):]
+	}
+
+	section := ""
+	pending := &CfgEntry{}
+	var description []string
+
+	for len(data) > 0 {
+		line, ending := splitCfgRawLine(&data)
+		index := len(file.lines)
+		file.lines = append(file.lines, line)
+		file.endings = append(file.endings, ending)
+
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "":
+			continue
+
+		case strings.HasPrefix(trimmed, "##"):
+			if section == "" {
+				file.Header = append(file.Header, strings.TrimSpace(strings.TrimPrefix(trimmed, "##")))
+				continue
+			}
+			description = append(description, strings.TrimSpace(strings.TrimPrefix(trimmed, "##")))
+
+		case strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, ";"):
+			parseCfgMetadata(pending, strings.TrimSpace(trimmed[1:]))
+
+		default:
+			key, value, isEntry := splitCfgLine(line, &section)
+			if !isEntry {
+				if strings.HasPrefix(trimmed, "[") {
+					pending = &CfgEntry{}
+					description = nil
+				}
+				continue
+			}
+
+			pending.Section = section
+			pending.Key = key
+			pending.Value = value
+			pending.Description = strings.Join(description, "\n")
+			pending.line = index
+			file.Entries = append(file.Entries, pending)
+
+			pending = &CfgEntry{}
+			description = nil
+		}
+	}
+
+	return file, nil
+}
+
+func splitCfgRawLine(data *[]byte) (string, string) {
+	i := bytes.IndexByte(*data, '\n')
+	if i < 0 {
+		line := string(*data)
+		*data = nil
+		return line, ""
+	}
+
+	line, ending := (*data)[:i], "\n"
+	if bytes.HasSuffix(line, []byte("\r")) {
+		line, ending = line[:len(line)-1], "\r\n"
+	}
+	*data = (*data)[i+1:]
+	return string(line), ending
+}
+
+func parseCfgMetadata(entry *CfgEntry, comment string) {
+	name, value, found := strings.Cut(comment, ":")
+	if !found {
+		return
+	}
+	value = strings.TrimSpace(value)
+
+	switch {
+	case name == "Setting type":
+		entry.Type = value
+
+	case name == "Default value":
+		entry.Default = value
+		entry.HasDefault = true
+
+	case strings.HasPrefix(name, "Acceptable values"):
+		entry.Multiple = strings.Contains(name, "combine multiple")
+		for _, option := range strings.Split(value, ",") {
+			if option = strings.TrimSpace(option); option != "" {
+				entry.AcceptableValues = append(entry.AcceptableValues, option)
+			}
+		}
+
+	case name == "Acceptable value range":
+		var lower, upper string
+		if _, err := fmt.Sscanf(value, "From %s to %s", &lower, &upper); err != nil {
+			return
+		}
+		minValue, errMin := strconv.ParseFloat(lower, 64)
+		maxValue, errMax := strconv.ParseFloat(upper, 64)
+		if errMin == nil && errMax == nil {
+			entry.Min, entry.Max, entry.HasRange = minValue, maxValue, true
+		}
+	}
+}
+
+func splitCfgLine(line string, section *string) (string, string, bool) {
+	trimmed := strings.TrimSpace(line)
+	if trimmed == "" || strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, ";") {
+		return "", "", false
+	}
+
+	if strings.HasPrefix(trimmed, "[") && strings.HasSuffix(trimmed, "]") {
+		*section = strings.TrimSpace(trimmed[1 : len(trimmed)-1])
+		return "", "", false
+	}
+
+	eq := strings.Index(trimmed, "=")
+	if eq < 0 {
+		return "", "", false
+	}
+
+	return strings.TrimSpace(trimmed[:eq]), strings.TrimSpace(trimmed[eq+1:]), true
+}
+
+func (f *CfgFile) Sections() []string {
+	var sections []string
+	seen := make(map[string]bool)
+	for _, entry := range f.Entries {
+		if !seen[entry.Section] {
+			seen[entry.Section] = true
+			sections = append(sections, entry.Section)
+		}
+	}
+	return sections
+}
+
+func (f *CfgFile) Entry(section, key string) *CfgEntry {
+	for _, entry := range f.Entries {
+		if entry.Section == section && entry.Key == key {
+			return entry
+		}
+	}
+	return nil
+}
+
+func (f *CfgFile) Set(section, key, value string) error {
+	entry := f.Entry(section, key)
+	if entry == nil {
+		return fmt.Errorf("config entry [%s] %s not found", section, key)
+	}
+
+	value = strings.TrimSpace(value)
+	if strings.ContainsAny(value, "\r\n") {
+		return fmt.Errorf("config entry [%s] %s: value must be a single line", section, key)
+	}
+
+	line := f.lines[entry.line]
+	eq := strings.Index(line, "=")
+	rest := line[eq+1:]
+	leading := rest[:len(rest)-len(strings.TrimLeft(rest, " \t"))]
+	trailing := rest[len(strings.TrimRight(rest, " \t")):]
+	if len(leading)+len(trailing) > len(rest) {
+		trailing = ""
+	}
+
+	f.lines[entry.line] = line[:eq+1] + leading + value + trailing
+	entry.Value = value
+	return nil
+}
+
+func (f *CfgFile) Bytes() []byte {
+	var buf bytes.Buffer
+	if f.bom {
+		buf.Write(utf8BOM)
+	}
+	for i, line := range f.lines {
+		buf.WriteString(line)
+		buf.WriteString(f.endings[i])
+	}
+	return buf.Bytes()
+}
@@ -0,0 +1,192 @@
+package profile
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+const bepInExCfgSample = `## Settings file was created by plugin BepInEx v5.4.21
+## Plugin GUID: BepInEx
+
+[Caching]
+
+## Enable/disable assembly metadata cache
+## Enabling this will speed up discovery of plugins and patchers by caching the metadata of all types BepInEx discovers.
+# Setting type: Boolean
+# Default value: true
+EnableAssemblyCache = true
+
+[Logging.Console]
+
+## Enables showing a console for log output.
+# Setting type: Boolean
+# Default value: false
+Enabled = false
+
+## Which log levels to show in the console output.
+# Setting type: LogLevel
+# Default value: Fatal, Error, Warning, Message, Info
+# Acceptable values: None, Fatal, Error, Warning, Message, Info, Debug, All
+# Multiple values can be set at the same time by separating them with , (e.g. Debug, Warning)
+LogLevels = Fatal, Error, Warning, Message, Info
+
+`
+
+const pluginCfgSample = `## Settings file was created by plugin MoreCompany v1.9.1
+## Plugin GUID: me.swipez.melonloader.morecompany
+
+[Cosmetics]
+
+## Whether or not to show cosmetics
+# Setting type: Boolean
+# Default value: true
+Show Cosmetics = true
+
+[General]
+
+## The maximum player count
+# Setting type: Int32
+# Default value: 32
+# Acceptable value range: From 4 to 50
+Player Count = 32
+
+## Custom lobby name
+# Setting type: String
+# Default value:
+Lobby Name =
+`
+
+func cfgVariants() map[string][]byte {
+	variants := make(map[string][]byte)
+	for name, sample := range map[string]string{"BepInEx.cfg": bepInExCfgSample, "plugin.cfg": pluginCfgSample} {
+		crlf := strings.ReplaceAll(sample, "\n", "\r\n")
+		variants[name+" LF"] = []byte(sample)
+		variants[name+" CRLF"] = []byte(crlf)
+		variants[name+" BOM CRLF"] = []byte("\xef\xbb\xbf" + crlf)
+		variants[name+" no final newline"] = []byte(strings.TrimRight(sample, "\n"))
+	}
+	variants["mixed line endings"] = []byte("[General]\r\nA = 1\nB = 2\r\n")
+	return variants
+}
+
+func TestParseCfgRoundTrip(t *testing.T) {
+	for name, data := range cfgVariants() {
+		t.Run(name, func(t *testing.T) {
+			file, err := ParseCfg(data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := file.Bytes(); !bytes.Equal(got, data) {
+				t.Errorf("Bytes() changed the file:\n got %q\nwant %q", got, data)
+			}
+		})
+	}
+}
+
+func TestParseCfgEntries(t *testing.T) {
+	file, err := ParseCfg([]byte("\xef\xbb\xbf" + strings.ReplaceAll(pluginCfgSample, "\n", "\r\n")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(file.Header) != 2 || file.Header[1] != "Plugin GUID: me.swipez.melonloader.morecompany" {
+		t.Errorf("header = %q", file.Header)
+	}
+
+	tests := []struct {
+		section, key string
+		value        string
+		kind         CfgValueKind
+	}{
+		{"Cosmetics", "Show Cosmetics", "true", CfgBool},
+		{"General", "Player Count", "32", CfgInt},
+		{"General", "Lobby Name", "", CfgString},
+	}
+	for _, tt := range tests {
+		entry := file.Entry(tt.section, tt.key)
+		if entry == nil {
+			t.Fatalf("[%s] %s not found", tt.section, tt.key)
+		}
+		if entry.Value != tt.value || entry.Kind() != tt.kind {
+			t.Errorf("[%s] %s = %q (kind %d), want %q (kind %d)", tt.section, tt.key, entry.Value, entry.Kind(), tt.value, tt.kind)
+		}
+	}
+
+	if entry := file.Entry("General", "Player Count"); !entry.HasRange || entry.Min != 4 || entry.Max != 50 {
+		t.Errorf("Player Count range = %v %v..%v", entry.HasRange, entry.Min, entry.Max)
+	}
+}
+
+func TestCfgSetChangesOnlyTheValue(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		section string
+		key     string
+		value   string
+		want    string
+	}{
+		{
+			name:    "BepInEx spacing",
+			input:   "\xef\xbb\xbf[General]\r\n\r\nPlayer Count = 32\r\n",
+			section: "General", key: "Player Count", value: "8",
+			want: "\xef\xbb\xbf[General]\r\n\r\nPlayer Count = 8\r\n",
+		},
+		{
+			name:    "no spaces around equals",
+			input:   "[General]\nRate=5\n",
+			section: "General", key: "Rate", value: "10",
+			want: "[General]\nRate=10\n",
+		},
+		{
+			name:    "wide spacing and trailing whitespace",
+			input:   "[General]\nRate   =   5  \nOther = 1",
+			section: "General", key: "Rate", value: "10",
+			want: "[General]\nRate   =   10  \nOther = 1",
+		},
+		{
+			name:    "empty value",
+			input:   "[General]\nLobby Name = \n",
+			section: "General", key: "Lobby Name", value: "My Lobby",
+			want: "[General]\nLobby Name = My Lobby\n",
+		},
+		{
+			name:    "clearing a value",
+			input:   "[General]\nLobby Name = My Lobby\n",
+			section: "General", key: "Lobby Name", value: "",
+			want: "[General]\nLobby Name = \n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file, err := ParseCfg([]byte(tt.input))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := file.Set(tt.section, tt.key, tt.value); err != nil {
+				t.Fatal(err)
+			}
+			if got := string(file.Bytes()); got != tt.want {
+				t.Errorf("Bytes() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCfgSetRejectsInvalidInput(t *testing.T) {
+	file, err := ParseCfg([]byte(pluginCfgSample))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := file.Set("General", "Missing", "1"); err == nil {
+		t.Error("Set() on a missing key succeeded")
+	}
+	if err := file.Set("General", "Lobby Name", "two\nlines"); err == nil {
+		t.Error("Set() with a multi-line value succeeded")
+	}
+	if got := file.Bytes(); !bytes.Equal(got, []byte(pluginCfgSample)) {
+		t.Errorf("failed Set() changed the file: %q", got)
+	}
+}
@@ -0,0 +1,280 @@
+package profile
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	configStateFile   = ".profile_configs.json"
+	configBaselineDir = ".profile_baseline"
+)
+
+type ConfigMergePolicy string
+
+const (
+	ConfigMerge    ConfigMergePolicy = "merge"
+	ConfigKeepUser ConfigMergePolicy = "keep"
+	ConfigTakeNew  ConfigMergePolicy = "replace"
+)
+
+type ConfigMergeAction string
+
+const (
+	ConfigActionKept     ConfigMergeAction = "kept"
+	ConfigActionReplaced ConfigMergeAction = "replaced"
+	ConfigActionMerged   ConfigMergeAction = "merged"
+	ConfigActionRestored ConfigMergeAction = "restored"
+)
+
+type ConfigConflict struct {
+	Section   string
+	Key       string
+	UserValue string
+	NewValue  string
+}
+
+type ConfigMergeResult struct {
+	Path      string
+	Action    ConfigMergeAction
+	Conflicts []ConfigConflict
+}
+
+type userConfig struct {
+	relPath  string
+	content  []byte
+	baseline []byte
+	tracked  bool
+}
+
+func (s *ProfileStore) walkFiles(dir string, fn func(filePath string) error) error {
+	entries, err := s.fsys.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		entryPath := filepath.Join(dir, entry.Name())
+		if entry.IsDir() {
+			err = s.walkFiles(entryPath, fn)
+		} else {
+			err = fn(entryPath)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *ProfileStore) listConfigFiles(profilePath string) ([]string, error) {
+	var files []string
+
+	for _, configPath := range []string{filepath.Join(profilePath, "BepInEx", "config"), filepath.Join(profilePath, "config")} {
+		err := s.walkFiles(configPath, func(p string) error {
+			rel, err := filepath.Rel(profilePath, p)
+			if err != nil {
+				return err
+			}
+			files = append(files, filepath.ToSlash(rel))
+			return nil
+		})
+		if err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to scan config files: %w", err)
+		}
+	}
+
+	sort.Strings(files)
+	return files, nil
+}
+
+func (s *ProfileStore) recordConfigState(profilePath string) error {
+	files, err := s.listConfigFiles(profilePath)
+	if err != nil {
+		return err
+	}
+
+	baselinePath := filepath.Join(profilePath, configBaselineDir)
+	if err := s.fsys.RemoveAll(baselinePath); err != nil {
+		return fmt.Errorf("failed to clear config baseline: %w", err)
+	}
+
+	hashes := make(map[string]string, len(files))
+	for _, rel := range files {
+		data, err := s.fsys.ReadFile(filepath.Join(profilePath, filepath.FromSlash(rel)))
+		if err != nil {
+			return fmt.Errorf("failed to read config %s: %w", rel, err)
+		}
+		hashes[rel] = hashBytes(data)
+
+		baselineFile := filepath.Join(baselinePath, filepath.FromSlash(rel))
+		if err := s.fsys.MkdirAll(filepath.Dir(baselineFile), 0755); err != nil {
+			return fmt.Errorf("failed to create config baseline directory: %w", err)
+		}
+		if err := s.fsys.WriteFile(baselineFile, data, 0644); err != nil {
+			return fmt.Errorf("failed to write config baseline %s: %w", rel, err)
+		}
+	}
+
+	data, err := json.MarshalIndent(hashes, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal config hashes: %w", err)
+	}
+
+	if err := s.fsys.WriteFile(filepath.Join(profilePath, configStateFile), data, 0644); err != nil {
+		return fmt.Errorf("failed to write config hashes: %w", err)
+	}
+
+	logger().Info("Recorded config file hashes", "count", len(hashes), "profile", profilePath)
+	return nil
+}
+
+func (s *ProfileStore) loadConfigHashes(profilePath string) (map[string]string, error) {
+	data, err := s.fsys.ReadFile(filepath.Join(profilePath, configStateFile))
+	if err != nil {
+		return nil, err
+	}
+
+	var hashes map[string]string
+	if err := json.Unmarshal(data, &hashes); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", configStateFile, err)
+	}
+	return hashes, nil
+}
+
+func (s *ProfileStore) collectUserConfigs(profilePath string) ([]userConfig, error) {
+	hashes, err := s.loadConfigHashes(profilePath)
+	if os.IsNotExist(err) {
+		logger().Warn("No recorded config hashes, user changes cannot be detected", "profile", profilePath)
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := s.listConfigFiles(profilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	var configs []userConfig
+	for _, rel := range files {
+		content, err := s.fsys.ReadFile(filepath.Join(profilePath, filepath.FromSlash(rel)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read config %s: %w", rel, err)
+		}
+
+		recordedHash, tracked := hashes[rel]
+		if tracked && recordedHash == hashBytes(content) {
+			continue
+		}
+
+		config := userConfig{relPath: rel, content: content, tracked: tracked}
+		if tracked {
+			baseline, err := s.fsys.ReadFile(filepath.Join(profilePath, configBaselineDir, filepath.FromSlash(rel)))
+			if err != nil {
+				logger().Warn("Missing config baseline", "path", rel, "error", err)
+			}
+			config.baseline = baseline
+		}
+		configs = append(configs, config)
+	}
+
+	return configs, nil
+}
+
+func (s *ProfileStore) applyConfigPolicy(profilePath string, configs []userConfig, policy ConfigMergePolicy) ([]ConfigMergeResult, error) {
+	var results []ConfigMergeResult
+
+	for _, config := range configs {
+		target := filepath.Join(profilePath, filepath.FromSlash(config.relPath))
+
+		updated, err := s.fsys.ReadFile(target)
+		exists := err == nil
+		if err != nil && !os.IsNotExist(err) {
+			return results, fmt.Errorf("failed to read updated config %s: %w", config.relPath, err)
+		}
+
+		result := ConfigMergeResult{Path: config.relPath}
+		content := config.content
+
+		switch {
+		case policy == ConfigTakeNew:
+			if !exists && !config.tracked {
+				continue
+			}
+			result.Action = ConfigActionReplaced
+			results = append(results, result)
+			continue
+
+		case !exists:
+			result.Action = ConfigActionRestored
+
+		case policy == ConfigMerge && strings.EqualFold(filepath.Ext(config.relPath), ".cfg"):
+			content, result.Conflicts = mergeCfg(config.baseline, config.content, updated)
+			result.Action = ConfigActionMerged
+
+		default:
+			result.Action = ConfigActionKept
+		}
+
+		if err := s.fsys.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return results, fmt.Errorf("failed to create config directory: %w", err)
+		}
+		if err := s.fsys.WriteFile(target, content, 0644); err != nil {
+			return results, fmt.Errorf("failed to write config %s: %w", config.relPath, err)
+		}
+
+		logger().Info("Applied config", "path", config.relPath, "action", result.Action, "conflicts", len(result.Conflicts))
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+func mergeCfg(base, user, updated []byte) ([]byte, []ConfigConflict) {
+	merged, err := ParseCfg(updated)
+	if err != nil {
+		return user, nil
+	}
+
+	baseFile, err := ParseCfg(base)
+	if err != nil {
+		baseFile = &CfgFile{}
+	}
+	userFile, err := ParseCfg(user)
+	if err != nil {
+		return user, nil
+	}
+
+	var conflicts []ConfigConflict
+	for _, entry := range merged.Entries {
+		userEntry := userFile.Entry(entry.Section, entry.Key)
+		if userEntry == nil || userEntry.Value == entry.Value {
+			continue
+		}
+
+		baseEntry := baseFile.Entry(entry.Section, entry.Key)
+		if baseEntry != nil && userEntry.Value == baseEntry.Value {
+			continue
+		}
+
+		if baseEntry == nil || entry.Value != baseEntry.Value {
+			conflicts = append(conflicts, ConfigConflict{
+				Section:   entry.Section,
+				Key:       entry.Key,
+				UserValue: userEntry.Value,
+				NewValue:  entry.Value,
+			})
+		}
+
+		if err := merged.Set(entry.Section, entry.Key, userEntry.Value); err != nil {
+			logger().Warn("Could not merge config value", "section", entry.Section, "key", entry.Key, "error", err)
+		}
+	}
+
+	return merged.Bytes(), conflicts
+}
@@ -0,0 +1,190 @@
+package profile
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+const testCfgBase = `[General]
+
+## Enables the mod
+# Setting type: Boolean
+# Default value: true
+Enabled = true
+
+## Spawn rate
+# Setting type: Int32
+# Default value: 5
+Rate = 5
+
+[Extra]
+
+Legacy = old
+`
+
+func cfgValues(t *testing.T, data []byte) map[string]string {
+	t.Helper()
+	file, err := ParseCfg(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	values := make(map[string]string)
+	for _, entry := range file.Entries {
+		values[entry.Section+"."+entry.Key] = entry.Value
+	}
+	return values
+}
+
+func editCfg(t *testing.T, data string, edits map[string]string) string {
+	t.Helper()
+	file, err := ParseCfg([]byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range slices.Clone(file.Entries) {
+		if value, ok := edits[entry.Section+"."+entry.Key]; ok {
+			if err := file.Set(entry.Section, entry.Key, value); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	return string(file.Bytes())
+}
+
+func TestMergeCfg(t *testing.T) {
+	upstreamWithoutLegacy := "[General]\n\nEnabled = true\n\nRate = 5\n"
+
+	tests := []struct {
+		name          string
+		user          string
+		updated       string
+		want          map[string]string
+		wantConflicts []ConfigConflict
+	}{
+		{
+			name:    "user-only change is kept",
+			user:    editCfg(t, testCfgBase, map[string]string{"General.Rate": "10"}),
+			updated: testCfgBase,
+			want:    map[string]string{"General.Enabled": "true", "General.Rate": "10", "Extra.Legacy": "old"},
+		},
+		{
+			name:    "upstream-only change is taken",
+			user:    testCfgBase,
+			updated: editCfg(t, testCfgBase, map[string]string{"General.Enabled": "false"}),
+			want:    map[string]string{"General.Enabled": "false", "General.Rate": "5", "Extra.Legacy": "old"},
+		},
+		{
+			name:    "both changed keeps the user value and reports a conflict",
+			user:    editCfg(t, testCfgBase, map[string]string{"General.Rate": "10"}),
+			updated: editCfg(t, testCfgBase, map[string]string{"General.Rate": "7"}),
+			want:    map[string]string{"General.Enabled": "true", "General.Rate": "10", "Extra.Legacy": "old"},
+			wantConflicts: []ConfigConflict{
+				{Section: "General", Key: "Rate", UserValue: "10", NewValue: "7"},
+			},
+		},
+		{
+			name:    "key removed upstream is dropped",
+			user:    editCfg(t, testCfgBase, map[string]string{"Extra.Legacy": "mine"}),
+			updated: upstreamWithoutLegacy,
+			want:    map[string]string{"General.Enabled": "true", "General.Rate": "5"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merged, conflicts := mergeCfg([]byte(testCfgBase), []byte(tt.user), []byte(tt.updated))
+
+			got := cfgValues(t, merged)
+			if len(got) != len(tt.want) {
+				t.Errorf("merged entries = %v, want %v", got, tt.want)
+			}
+			for key, want := range tt.want {
+				if got[key] != want {
+					t.Errorf("%s = %q, want %q", key, got[key], want)
+				}
+			}
+			if !slices.Equal(conflicts, tt.wantConflicts) {
+				t.Errorf("conflicts = %+v, want %+v", conflicts, tt.wantConflicts)
+			}
+		})
+	}
+}
+
+func TestApplyConfigPolicy(t *testing.T) {
+	const rel = "BepInEx/config/Mod.cfg"
+	user := editCfg(t, testCfgBase, map[string]string{"General.Rate": "10"})
+	updated := editCfg(t, testCfgBase, map[string]string{"General.Enabled": "false"})
+
+	tests := []struct {
+		policy     ConfigMergePolicy
+		wantAction ConfigMergeAction
+		want       map[string]string
+	}{
+		{
+			policy:     ConfigMerge,
+			wantAction: ConfigActionMerged,
+			want:       map[string]string{"General.Enabled": "false", "General.Rate": "10"},
+		},
+		{
+			policy:     ConfigKeepUser,
+			wantAction: ConfigActionKept,
+			want:       map[string]string{"General.Enabled": "true", "General.Rate": "10"},
+		},
+		{
+			policy:     ConfigTakeNew,
+			wantAction: ConfigActionReplaced,
+			want:       map[string]string{"General.Enabled": "false", "General.Rate": "5"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.policy), func(t *testing.T) {
+			store := newTestStore(t)
+			profilePath := store.ProfilePath(testGame("1.0.0"))
+			target := filepath.Join(profilePath, filepath.FromSlash(rel))
+			writeTestFile(t, store, target, updated)
+
+			configs := []userConfig{{relPath: rel, content: []byte(user), baseline: []byte(testCfgBase), tracked: true}}
+			results, err := store.applyConfigPolicy(profilePath, configs, tt.policy)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(results) != 1 || results[0].Path != rel || results[0].Action != tt.wantAction {
+				t.Fatalf("results = %+v, want %s for %s", results, tt.wantAction, rel)
+			}
+
+			data, err := store.fsys.ReadFile(target)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := cfgValues(t, data)
+			for key, want := range tt.want {
+				if got[key] != want {
+					t.Errorf("%s = %q, want %q", key, got[key], want)
+				}
+			}
+		})
+	}
+}
+
+func TestApplyConfigPolicyRestoresDeletedConfig(t *testing.T) {
+	const rel = "BepInEx/config/Removed.cfg"
+	store := newTestStore(t)
+	profilePath := store.ProfilePath(testGame("1.0.0"))
+	if err := store.fsys.MkdirAll(profilePath, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	configs := []userConfig{{relPath: rel, content: []byte(testCfgBase)}}
+	results, err := store.applyConfigPolicy(profilePath, configs, ConfigMerge)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || results[0].Action != ConfigActionRestored {
+		t.Fatalf("results = %+v, want %s", results, ConfigActionRestored)
+	}
+	if data, err := store.fsys.ReadFile(filepath.Join(profilePath, filepath.FromSlash(rel))); err != nil || string(data) != testCfgBase {
+		t.Errorf("restored config = %q, %v", data, err)
+	}
+}
@@ -0,0 +1,360 @@
+package profile
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/ur-wesley/modhelper/internal"
+)
+
+const profileBackupDir = ".modhelper_backup"
+
+type ModChange struct {
+	Name        string
+	FromVersion string
+	ToVersion   string
+}
+
+type ProfileDiff struct {
+	FromVersion string
+	ToVersion   string
+	Changelog   string
+
+	Added      []ModChange
+	Removed    []ModChange
+	Upgraded   []ModChange
+	Downgraded []ModChange
+
+	ConfigAdded   []string
+	ConfigRemoved []string
+	ConfigChanged []string
+	UserModified  []string
+
+	archive []byte
+}
+
+func (d *ProfileDiff) HasModChanges() bool {
+	return len(d.Added)+len(d.Removed)+len(d.Upgraded)+len(d.Downgraded) > 0
+}
+
+func (d *ProfileDiff) HasConfigChanges() bool {
+	return len(d.ConfigAdded)+len(d.ConfigRemoved)+len(d.ConfigChanged) > 0
+}
+
+func (s *ProfileStore) PreviewUpdate(game internal.Game) (*ProfileDiff, error) {
+	buf, err := s.downloadProfileArchive(game)
+	if err != nil {
+		return nil, err
+	}
+
+	installedVersion, err := s.GetInstalledProfileVersion(game)
+	if err != nil {
+		logger().Warn("Could not read installed version", "game", game.Name, "error", err)
+	}
+
+	diff := &ProfileDiff{
+		FromVersion: installedVersion,
+		ToVersion:   game.Version,
+		Changelog:   game.Changelog,
+		archive:     buf,
+	}
+
+	zipReader, err := zip.NewReader(bytes.NewReader(buf), int64(len(buf)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ZIP data: %w", err)
+	}
+
+	profilePath := s.ProfilePath(game)
+
+	installedMods, err := s.readInstalledModVersions(profilePath)
+	if err != nil {
+		logger().Warn("Could not read installed mods", "game", game.Name, "error", err)
+	}
+
+	newMods, err := readArchiveModVersions(zipReader)
+	if err != nil {
+		return nil, err
+	}
+
+	diffMods(diff, installedMods, newMods)
+
+	if err := s.diffConfigFiles(diff, zipReader, profilePath); err != nil {
+		return nil, err
+	}
+
+	userConfigs, err := s.collectUserConfigs(profilePath)
+	if err != nil {
+		logger().Warn("Could not detect user config changes", "game", game.Name, "error", err)
+	}
+	for _, userConfig := range userConfigs {
+		if userConfig.tracked {
+			diff.UserModified = append(diff.UserModified, userConfig.relPath)
+		}
+	}
+
+	logger().Info("Computed update diff", "game", game.Name, "added", len(diff.Added), "removed", len(diff.Removed),
+		"upgraded", len(diff.Upgraded), "downgraded", len(diff.Downgraded),
+		"config_changed", len(diff.ConfigAdded)+len(diff.ConfigRemoved)+len(diff.ConfigChanged))
+
+	return diff, nil
+}
+
+func (s *ProfileStore) ApplyUpdate(game internal.Game, diff *ProfileDiff, policy ConfigMergePolicy) ([]ConfigMergeResult, error) {
+	if diff == nil || diff.archive == nil {
+		return nil, fmt.Errorf("no downloaded profile to apply for %s", game.Name)
+	}
+
+	profilePath := s.ProfilePath(game)
+
+	userConfigs, err := s.collectUserConfigs(profilePath)
+	if err != nil {
+		logger().Warn("Could not collect user config changes", "game", game.Name, "error", err)
+	}
+
+	backupPath, err := s.backupProfile(game)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.installProfileArchive(game, diff.archive); err != nil {
+		if restoreErr := s.restoreProfileBackup(game, backupPath); restoreErr != nil {
+			logger().Error("Could not restore the previous profile", "game", game.Name, "backup", backupPath, "error", restoreErr)
+		}
+		return nil, err
+	}
+
+	if backupPath != "" {
+		if err := s.fsys.RemoveAll(backupPath); err != nil {
+			logger().Warn("Could not remove profile backup", "path", backupPath, "error", err)
+		}
+	}
+
+	results, err := s.applyConfigPolicy(profilePath, userConfigs, policy)
+	if err != nil {
+		return results, fmt.Errorf("failed to restore user config files: %w", err)
+	}
+
+	return results, nil
+}
+
+func (s *ProfileStore) profileBackupPath(game internal.Game) string {
+	return filepath.Join(filepath.Dir(s.GameDir(game)), profileBackupDir, GetProfileName(game))
+}
+
+func (s *ProfileStore) backupProfile(game internal.Game) (string, error) {
+	profilePath := s.ProfilePath(game)
+	if _, err := s.fsys.Stat(profilePath); os.IsNotExist(err) {
+		return "", nil
+	}
+
+	backupPath := s.profileBackupPath(game)
+	if err := s.fsys.RemoveAll(backupPath); err != nil {
+		return "", fmt.Errorf("failed to clear old profile backup: %w", err)
+	}
+	if err := s.fsys.MkdirAll(filepath.Dir(backupPath), 0755); err != nil {
+		return "", fmt.Errorf("failed to create profile backup directory: %w", err)
+	}
+	if err := s.fsys.Rename(profilePath, backupPath); err != nil {
+		return "", fmt.Errorf("failed to move old profile aside: %w", err)
+	}
+	s.invalidateStatus(profilePath)
+
+	logger().Info("Moved old profile aside", "game", game.Name, "backup", backupPath)
+	return backupPath, nil
+}
+
+func (s *ProfileStore) restoreProfileBackup(game internal.Game, backupPath string) error {
+	profilePath := s.ProfilePath(game)
+	defer s.invalidateStatus(profilePath)
+
+	if err := s.fsys.RemoveAll(profilePath); err != nil {
+		return fmt.Errorf("failed to remove partially installed profile: %w", err)
+	}
+	if backupPath == "" {
+		return nil
+	}
+	if err := s.fsys.Rename(backupPath, profilePath); err != nil {
+		return fmt.Errorf("failed to move profile backup back: %w", err)
+	}
+
+	logger().Info("Restored previous profile after failed update", "game", game.Name)
+	return nil
+}
+
+func (s *ProfileStore) readInstalledModVersions(profilePath string) (map[string]string, error) {
+	data, err := s.fsys.ReadFile(filepath.Join(profilePath, "mods.yml"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return map[string]string{}, nil
+		}
+		return nil, err
+	}
+
+	var modsYML ModsYML
+	if err := yaml.Unmarshal(data, &modsYML); err != nil {
+		return nil, fmt.Errorf("failed to parse mods.yml: %w", err)
+	}
+
+	mods := make(map[string]string)
+	for _, mod := range modsYML {
+		if mod.Name == "_ProfileVersion" || !mod.Enabled {
+			continue
+		}
+		mods[mod.Name] = fmt.Sprintf("%d.%d.%d", mod.VersionNumber.Major, mod.VersionNumber.Minor, mod.VersionNumber.Patch)
+	}
+	return mods, nil
+}
+
+func readArchiveModVersions(zipReader *zip.Reader) (map[string]string, error) {
+	mods := make(map[string]string)
+
+	for _, file := range zipReader.File {
+		switch file.Name {
+		case "export.r2x":
+			data, err := readZipFile(file)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read export.r2x: %w", err)
+			}
+
+			var exportR2X ExportFormatR2X
+			if err := yaml.Unmarshal(data, &exportR2X); err != nil {
+				return nil, fmt.Errorf("failed to parse export.r2x: %w", err)
+			}
+
+			for _, mod := range exportR2X.Mods {
+				if mod.Enabled {
+					mods[mod.Name] = fmt.Sprintf("%d.%d.%d", mod.Version.Major, mod.Version.Minor, mod.Version.Patch)
+				}
+			}
+			return mods, nil
+
+		case "mods.yml":
+			data, err := readZipFile(file)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read mods.yml: %w", err)
+			}
+
+			var modsYML ModsYML
+			if err := yaml.Unmarshal(data, &modsYML); err != nil {
+				return nil, fmt.Errorf("failed to parse mods.yml: %w", err)
+			}
+
+			for _, mod := range modsYML {
+				if mod.Name != "_ProfileVersion" && mod.Enabled {
+					mods[mod.Name] = fmt.Sprintf("%d.%d.%d", mod.VersionNumber.Major, mod.VersionNumber.Minor, mod.VersionNumber.Patch)
+				}
+			}
+		}
+	}
+
+	return mods, nil
+}
+
+func diffMods(diff *ProfileDiff, installed, available map[string]string) {
+	for name, toVersion := range available {
+		fromVersion, exists := installed[name]
+		if !exists {
+			diff.Added = append(diff.Added, ModChange{Name: name, ToVersion: toVersion})
+			continue
+		}
+
+		switch change := compareProfileVersions(fromVersion, toVersion); change {
+		case VersionUpdate:
+			diff.Upgraded = append(diff.Upgraded, ModChange{Name: name, FromVersion: fromVersion, ToVersion: toVersion})
+		case VersionDowngrade:
+			diff.Downgraded = append(diff.Downgraded, ModChange{Name: name, FromVersion: fromVersion, ToVersion: toVersion})
+		}
+	}
+
+	for name, fromVersion := range installed {
+		if _, exists := available[name]; !exists {
+			diff.Removed = append(diff.Removed, ModChange{Name: name, FromVersion: fromVersion})
+		}
+	}
+
+	for _, changes := range [][]ModChange{diff.Added, diff.Removed, diff.Upgraded, diff.Downgraded} {
+		sort.Slice(changes, func(i, j int) bool { return changes[i].Name < changes[j].Name })
+	}
+}
+
+func (s *ProfileStore) diffConfigFiles(diff *ProfileDiff, zipReader *zip.Reader, profilePath string) error {
+	archiveConfigs := make(map[string]bool)
+
+	for _, file := range zipReader.File {
+		if file.FileInfo().IsDir() || !isConfigPath(file.Name) {
+			continue
+		}
+
+		relPath := installedConfigPath(file.Name)
+		archiveConfigs[relPath] = true
+
+		installed, err := s.fsys.ReadFile(filepath.Join(profilePath, filepath.FromSlash(relPath)))
+		if os.IsNotExist(err) {
+			diff.ConfigAdded = append(diff.ConfigAdded, relPath)
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read installed config %s: %w", relPath, err)
+		}
+
+		data, err := readZipFile(file)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", file.Name, err)
+		}
+		if hashBytes(data) != hashBytes(installed) {
+			diff.ConfigChanged = append(diff.ConfigChanged, relPath)
+		}
+	}
+
+	for _, configPath := range []string{filepath.Join(profilePath, "BepInEx", "config"), filepath.Join(profilePath, "config")} {
+		err := s.walkFiles(configPath, func(p string) error {
+			rel, err := filepath.Rel(profilePath, p)
+			if err != nil {
+				return err
+			}
+			rel = filepath.ToSlash(rel)
+			if !archiveConfigs[rel] {
+				diff.ConfigRemoved = append(diff.ConfigRemoved, rel)
+			}
+			return nil
+		})
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to scan installed config files: %w", err)
+		}
+	}
+
+	sort.Strings(diff.ConfigAdded)
+	sort.Strings(diff.ConfigRemoved)
+	sort.Strings(diff.ConfigChanged)
+	return nil
+}
+
+func isConfigPath(name string) bool {
+	name = strings.ToLower(path.Clean(strings.ReplaceAll(name, "\\", "/")))
+	return strings.HasPrefix(name, "config/") || strings.HasPrefix(name, "bepinex/config/")
+}
+
+func readZipFile(file *zip.File) ([]byte, error) {
+	rc, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(rc)
+}
+
+func hashBytes(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,140 @@
+package profile
+
+import (
+	"archive/zip"
+	"bytes"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func buildTestArchiveBytes(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	zipWriter := zip.NewWriter(&buf)
+	for name, content := range files {
+		if err := writeZipFile(zipWriter, name, []byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zipWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func buildTestArchive(t *testing.T, files map[string]string) *zip.Reader {
+	t.Helper()
+
+	data := buildTestArchiveBytes(t, files)
+	reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return reader
+}
+
+func TestDiffConfigFilesMapsArchiveConfigDir(t *testing.T) {
+	store := newTestStore(t)
+	game := testGame("1.0.0")
+	profilePath := store.ProfilePath(game)
+
+	writeTestFile(t, store, filepath.Join(profilePath, "BepInEx", "config", "Same.cfg"), "[General]\nValue = 1\n")
+	writeTestFile(t, store, filepath.Join(profilePath, "BepInEx", "config", "Changed.cfg"), "[General]\nValue = 1\n")
+	writeTestFile(t, store, filepath.Join(profilePath, "BepInEx", "config", "Old.cfg"), "[General]\n")
+
+	archive := buildTestArchive(t, map[string]string{
+		"export.r2x":          "profileName: Test\nmods: []\n",
+		"config/Same.cfg":     "[General]\nValue = 1\n",
+		"config/Changed.cfg":  "[General]\nValue = 2\n",
+		"config/New.cfg":      "[General]\n",
+		"config/sub/Deep.cfg": "[General]\n",
+	})
+
+	diff := &ProfileDiff{}
+	if err := store.diffConfigFiles(diff, archive, profilePath); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		got  []string
+		want []string
+	}{
+		{"added", diff.ConfigAdded, []string{"BepInEx/config/New.cfg", "BepInEx/config/sub/Deep.cfg"}},
+		{"removed", diff.ConfigRemoved, []string{"BepInEx/config/Old.cfg"}},
+		{"changed", diff.ConfigChanged, []string{"BepInEx/config/Changed.cfg"}},
+	}
+	for _, tt := range tests {
+		if !slices.Equal(tt.got, tt.want) {
+			t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestApplyUpdate(t *testing.T) {
+	const userCfg = "[General]\nValue = mine\n"
+
+	tests := []struct {
+		name        string
+		archive     map[string]string
+		wantErr     bool
+		wantVersion string
+		wantConfig  string
+	}{
+		{
+			name: "successful update keeps user edits",
+			archive: map[string]string{
+				"mods.yml":               "[]\n",
+				"BepInEx/config/Mod.cfg": "[General]\nValue = 2\n",
+				"BepInEx/config/New.cfg": "[General]\n",
+				"doorstop_config.ini":    "[General]\n",
+			},
+			wantVersion: "1.1.0",
+			wantConfig:  userCfg,
+		},
+		{
+			name: "failed install restores the old profile",
+			archive: map[string]string{
+				"export.r2x":     "profileName: Test\nmods: []\n",
+				"config/Mod.cfg": "[General]\nValue = 2\n",
+			},
+			wantErr:     true,
+			wantVersion: "1.0.0",
+			wantConfig:  userCfg,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newTestStore(t)
+			installed := testGame("1.0.0")
+			profilePath := store.ProfilePath(installed)
+			configPath := filepath.Join(profilePath, "BepInEx", "config", "Mod.cfg")
+
+			installTestProfile(t, store, installed, false)
+			writeTestFile(t, store, configPath, "[General]\nValue = 1\n")
+			if err := store.recordConfigState(profilePath); err != nil {
+				t.Fatal(err)
+			}
+			writeTestFile(t, store, configPath, userCfg)
+
+			diff := &ProfileDiff{archive: buildTestArchiveBytes(t, tt.archive)}
+			_, err := store.ApplyUpdate(testGame("1.1.0"), diff, ConfigKeepUser)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ApplyUpdate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if version, err := store.GetInstalledProfileVersion(installed); err != nil || version != tt.wantVersion {
+				t.Errorf("installed version = %q, %v, want %q", version, err, tt.wantVersion)
+			}
+			if data, err := store.fsys.ReadFile(configPath); err != nil || string(data) != tt.wantConfig {
+				t.Errorf("user config = %q, %v, want %q", data, err, tt.wantConfig)
+			}
+			if _, err := store.fsys.Stat(store.profileBackupPath(installed)); err == nil {
+				t.Error("profile backup was left behind")
+			}
+		})
+	}
+}
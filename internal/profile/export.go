@@ -0,0 +1,146 @@
+package profile
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/ur-wesley/modhelper/internal"
+)
+
+type ProfileExport struct {
+	ProfileName string
+	Archive     []byte
+	SHA256      string
+	ModCount    int
+	ConfigCount int
+}
+
+func (s *ProfileStore) ExportProfile(game internal.Game) (*ProfileExport, error) {
+	profilePath := s.ProfilePath(game)
+
+	data, err := s.fsys.ReadFile(filepath.Join(profilePath, "mods.yml"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read mods.yml: %w", err)
+	}
+
+	var modsYML ModsYML
+	if err := yaml.Unmarshal(data, &modsYML); err != nil {
+		return nil, fmt.Errorf("failed to parse mods.yml: %w", err)
+	}
+
+	exportR2X := ExportFormatR2X{ProfileName: GetProfileName(game)}
+	for _, mod := range modsYML {
+		if mod.Name == "_ProfileVersion" {
+			continue
+		}
+
+		var info ModInfo
+		info.Name = mod.Name
+		info.Version.Major = mod.VersionNumber.Major
+		info.Version.Minor = mod.VersionNumber.Minor
+		info.Version.Patch = mod.VersionNumber.Patch
+		info.Enabled = mod.Enabled
+		exportR2X.Mods = append(exportR2X.Mods, info)
+	}
+
+	r2xData, err := yaml.Marshal(exportR2X)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal export.r2x: %w", err)
+	}
+
+	configFiles, err := s.listConfigFiles(profilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	zipWriter := zip.NewWriter(&buf)
+
+	if err := writeZipFile(zipWriter, "export.r2x", r2xData); err != nil {
+		return nil, err
+	}
+
+	for _, rel := range configFiles {
+		content, err := s.fsys.ReadFile(filepath.Join(profilePath, filepath.FromSlash(rel)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read config %s: %w", rel, err)
+		}
+		if err := writeZipFile(zipWriter, archiveConfigPath(rel), content); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := zipWriter.Close(); err != nil {
+		return nil, fmt.Errorf("failed to finish r2z archive: %w", err)
+	}
+
+	export := &ProfileExport{
+		ProfileName: exportR2X.ProfileName,
+		Archive:     buf.Bytes(),
+		SHA256:      hashBytes(buf.Bytes()),
+		ModCount:    len(exportR2X.Mods),
+		ConfigCount: len(configFiles),
+	}
+
+	logger().Info("Exported profile", "profile", export.ProfileName, "game", game.Name,
+		"mods", export.ModCount, "configs", export.ConfigCount, "sha256", export.SHA256)
+	return export, nil
+}
+
+func writeZipFile(zipWriter *zip.Writer, name string, data []byte) error {
+	w, err := zipWriter.CreateHeader(&zip.FileHeader{
+		Name:     name,
+		Method:   zip.Deflate,
+		Modified: time.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add %s to archive: %w", name, err)
+	}
+	if _, err := w.Write(data); err != nil {
+		return fmt.Errorf("failed to write %s to archive: %w", name, err)
+	}
+	return nil
+}
+
+func (e *ProfileExport) WriteFile(path string) error {
+	if err := os.WriteFile(path, e.Archive, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	logger().Info("Wrote profile export", "path", path)
+	return nil
+}
+
+func ManifestSnippet(game internal.Game, export *ProfileExport, url, version string) ([]byte, error) {
+	var entry any
+	if game.Variant != "" {
+		entry = internal.ProfileVariant{
+			Name:        game.Variant,
+			ProfileName: export.ProfileName,
+			URL:         url,
+			Version:     version,
+			SHA256:      export.SHA256,
+			MinVersion:  game.MinVersion,
+			Changelog:   game.Changelog,
+			LaunchArgs:  game.LaunchArgs,
+		}
+	} else {
+		game.ProfileName = export.ProfileName
+		game.URL = url
+		game.Version = version
+		game.SHA256 = export.SHA256
+		entry = game
+	}
+
+	data, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal manifest entry: %w", err)
+	}
+	return data, nil
+}
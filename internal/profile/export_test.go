@@ -0,0 +1,46 @@
+package profile
+
+import (
+	"archive/zip"
+	"bytes"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestExportProfileWritesArchiveConfigPaths(t *testing.T) {
+	store := newTestStore(t)
+	game := testGame("1.0.0")
+	profilePath := store.ProfilePath(game)
+
+	writeTestFile(t, store, filepath.Join(profilePath, "mods.yml"), "[]\n")
+	writeTestFile(t, store, filepath.Join(profilePath, "BepInEx", "config", "BepInEx.cfg"), "[Logging]\n")
+	writeTestFile(t, store, filepath.Join(profilePath, "BepInEx", "config", "sub", "Mod.cfg"), "[General]\n")
+
+	export, err := store.ExportProfile(game)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reader, err := zip.NewReader(bytes.NewReader(export.Archive), int64(len(export.Archive)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	for _, file := range reader.File {
+		names = append(names, file.Name)
+	}
+	want := []string{"export.r2x", "config/BepInEx.cfg", "config/sub/Mod.cfg"}
+	if !slices.Equal(names, want) {
+		t.Errorf("archive entries = %v, want %v", names, want)
+	}
+
+	diff := &ProfileDiff{}
+	if err := store.diffConfigFiles(diff, reader, profilePath); err != nil {
+		t.Fatal(err)
+	}
+	if diff.HasConfigChanges() {
+		t.Errorf("re-importing the export reports config changes: %+v", diff)
+	}
+}
@@ -4,76 +4,98 @@ import (
 	"archive/zip"
 	"fmt"
 	"io"
-	"log"
-	"os"
+	"path"
 	"path/filepath"
 	"strings"
 )
 
-func extractFileToPath(file *zip.File, outputPath string) error {
-	rc, err := file.Open()
-	if err != nil {
-		return err
+const (
+	archiveConfigDir   = "config"
+	installedConfigDir = "BepInEx/config"
+)
+
+func installedConfigPath(archiveName string) string {
+	name := path.Clean(strings.ReplaceAll(archiveName, "\\", "/"))
+	if rel, ok := strings.CutPrefix(name, archiveConfigDir+"/"); ok {
+		return path.Join(installedConfigDir, rel)
 	}
-	defer rc.Close()
+	return name
+}
 
-	dir := filepath.Dir(outputPath)
-	err = os.MkdirAll(dir, 0755)
-	if err != nil {
-		return err
+func archiveConfigPath(installedRel string) string {
+	name := path.Clean(strings.ReplaceAll(installedRel, "\\", "/"))
+	if rel, ok := strings.CutPrefix(name, installedConfigDir+"/"); ok {
+		return path.Join(archiveConfigDir, rel)
+	}
+	return name
+}
+
+func safeJoin(root, name string) (string, error) {
+	path := filepath.Join(root, filepath.FromSlash(strings.ReplaceAll(name, "\\", "/")))
+	if !isWithin(root, path) {
+		return "", fmt.Errorf("archive entry %s escapes %s", name, root)
 	}
+	return path, nil
+}
+
+func isWithin(root, path string) bool {
+	rel, err := filepath.Rel(root, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
 
+func (s *ProfileStore) extractFileToPath(file *zip.File, outputPath string) error {
 	if strings.HasSuffix(file.Name, "/") {
-		return nil
+		return s.fsys.MkdirAll(outputPath, 0755)
 	}
 
-	outFile, err := os.Create(outputPath)
-	if err != nil {
+	if err := s.fsys.MkdirAll(filepath.Dir(outputPath), 0755); err != nil {
 		return err
 	}
-	defer outFile.Close()
 
-	_, err = io.Copy(outFile, rc)
-	return err
+	return s.extractFileFromZip(file, outputPath)
 }
 
-func extractFileFromZip(file *zip.File, destPath string) error {
+func (s *ProfileStore) extractFileFromZip(file *zip.File, destPath string) error {
 	rc, err := file.Open()
 	if err != nil {
 		return err
 	}
 	defer rc.Close()
 
-	outFile, err := os.Create(destPath)
+	outFile, err := s.fsys.Create(destPath)
 	if err != nil {
 		return err
 	}
-	defer outFile.Close()
 
-	_, err = io.Copy(outFile, rc)
-	return err
+	if _, err := io.Copy(outFile, rc); err != nil {
+		outFile.Close()
+		return err
+	}
+	return outFile.Close()
 }
 
-func extractConfigFiles(reader *zip.ReadCloser, configPath string) error {
+func (s *ProfileStore) extractConfigFiles(reader *zip.Reader, configPath string) error {
 	for _, file := range reader.File {
-		if strings.HasPrefix(file.Name, "config/") {
-			relativePath := strings.TrimPrefix(file.Name, "config/")
+		if strings.HasPrefix(file.Name, archiveConfigDir+"/") {
+			relativePath := strings.TrimPrefix(file.Name, archiveConfigDir+"/")
 			if relativePath == "" || strings.HasSuffix(relativePath, "/") {
 				continue
 			}
 
-			outputPath := filepath.Join(configPath, relativePath)
-			err := extractFileToPath(file, outputPath)
+			outputPath, err := safeJoin(configPath, relativePath)
 			if err != nil {
+				return err
+			}
+			if err := s.extractFileToPath(file, outputPath); err != nil {
 				return fmt.Errorf("failed to extract config file %s: %w", file.Name, err)
 			}
-			log.Printf("Extracted config: %s\n", relativePath)
+			logger().Debug("Extracted config", "path", relativePath)
 		}
 	}
 	return nil
 }
 
-func extractOtherFiles(reader *zip.ReadCloser, profilePath string) error {
+func (s *ProfileStore) extractOtherFiles(reader *zip.Reader, profilePath string) error {
 	for _, file := range reader.File {
 		fileName := strings.ToLower(file.Name)
 
@@ -84,12 +106,14 @@ func extractOtherFiles(reader *zip.ReadCloser, profilePath string) error {
 			continue
 		}
 
-		outputPath := filepath.Join(profilePath, file.Name)
-		err := extractFileToPath(file, outputPath)
+		outputPath, err := safeJoin(profilePath, file.Name)
 		if err != nil {
+			return err
+		}
+		if err := s.extractFileToPath(file, outputPath); err != nil {
 			return fmt.Errorf("failed to extract file %s: %w", file.Name, err)
 		}
-		log.Printf("Extracted file: %s\n", file.Name)
+		logger().Debug("Extracted file", "file", file.Name)
 	}
 	return nil
 }
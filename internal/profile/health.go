@@ -0,0 +1,59 @@
+package profile
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/ur-wesley/modhelper/internal"
+	"github.com/ur-wesley/modhelper/internal/thunderstore"
+)
+
+type PackageHealth struct {
+	Name    string
+	Version string
+	Flags   []thunderstore.HealthFlag
+	Error   error
+}
+
+func (s *ProfileStore) CheckProfileHealth(game internal.Game) ([]PackageHealth, error) {
+	if game.Community == "" {
+		return nil, fmt.Errorf("no community found for game %s", game.Name)
+	}
+
+	data, err := s.fsys.ReadFile(filepath.Join(s.ProfilePath(game), "mods.yml"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read mods.yml: %w", err)
+	}
+
+	var modsYML ModsYML
+	if err := yaml.Unmarshal(data, &modsYML); err != nil {
+		return nil, fmt.Errorf("failed to parse mods.yml: %w", err)
+	}
+
+	var results []PackageHealth
+	for _, mod := range modsYML {
+		if mod.Name == "_ProfileVersion" || !mod.Enabled {
+			continue
+		}
+
+		version := fmt.Sprintf("%d.%d.%d", mod.VersionNumber.Major, mod.VersionNumber.Minor, mod.VersionNumber.Patch)
+		resolved, err := s.resolveMod(mod.Name, version, game.Community)
+
+		health := PackageHealth{Name: mod.Name, Version: version, Flags: resolved.Health}
+		if err != nil && !thunderstore.HasFlag(resolved.Health, thunderstore.HealthMissing) {
+			health.Error = err
+		}
+		results = append(results, health)
+	}
+
+	flagged := 0
+	for _, result := range results {
+		if len(result.Flags) > 0 || result.Error != nil {
+			flagged++
+		}
+	}
+	logger().Info("Health check finished", "game", game.Name, "packages", len(results), "flagged", flagged)
+	return results, nil
+}
@@ -2,9 +2,10 @@ package profile
 
 import (
 	"archive/zip"
+	"bytes"
+	"errors"
 	"fmt"
 	"io"
-	"log"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -14,39 +15,57 @@ import (
 	"gopkg.in/yaml.v3"
 
 	"github.com/ur-wesley/modhelper/internal"
-	"github.com/ur-wesley/modhelper/internal/config"
+	"github.com/ur-wesley/modhelper/internal/thunderstore"
 )
 
-func DownloadAndInstall(game internal.Game, targetDir string) error {
+func (s *ProfileStore) DownloadAndInstall(game internal.Game) error {
+	buf, err := s.downloadProfileArchive(game)
+	if err != nil {
+		return err
+	}
+	return s.installProfileArchive(game, buf)
+}
+
+func (s *ProfileStore) downloadProfileArchive(game internal.Game) ([]byte, error) {
 	if game.URL == "" {
-		return fmt.Errorf("no download URL for game %s", game.Name)
+		return nil, fmt.Errorf("no download URL for game %s", game.Name)
 	}
 
-	log.Printf("Downloading profile for %s from %s", game.Name, game.URL)
+	logger().Info("Downloading profile", "game", game.Name, "url", game.URL)
 
-	client := &http.Client{Timeout: 60 * time.Second}
-	resp, err := client.Get(game.URL)
+	resp, err := s.client.HTTPClient.Get(game.URL)
 	if err != nil {
-		return fmt.Errorf("failed to download profile: %w", err)
+		return nil, fmt.Errorf("failed to download profile: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("download failed with status: %d", resp.StatusCode)
+		return nil, fmt.Errorf("download failed with status: %d", resp.StatusCode)
 	}
 
 	buf, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("failed to read download data: %w", err)
+		return nil, fmt.Errorf("failed to read download data: %w", err)
 	}
 
-	log.Printf("Downloaded profile for %s (version: %s)", game.Name, game.Version)
+	if game.SHA256 != "" {
+		if checksum := hashBytes(buf); !strings.EqualFold(checksum, game.SHA256) {
+			return nil, fmt.Errorf("profile checksum mismatch: expected %s, got %s", game.SHA256, checksum)
+		}
+	}
+
+	logger().Info("Downloaded profile", "game", game.Name, "version", game.Version)
+	return buf, nil
+}
 
-	zipReader, err := zip.NewReader(strings.NewReader(string(buf)), int64(len(buf)))
+func (s *ProfileStore) installProfileArchive(game internal.Game, buf []byte) error {
+	zipReader, err := zip.NewReader(bytes.NewReader(buf), int64(len(buf)))
 	if err != nil {
 		return fmt.Errorf("failed to read ZIP data: %w", err)
 	}
 
+	defer s.invalidateStatus(s.ProfilePath(game))
+
 	isR2ZFile := false
 	for _, f := range zipReader.File {
 		if f.Name == "export.r2x" {
@@ -55,54 +74,38 @@ func DownloadAndInstall(game internal.Game, targetDir string) error {
 		}
 	}
 
-	profileDir := config.GetGameProfileDir(game)
+	profileDir := s.GameDir(game)
 
 	if isR2ZFile {
-		log.Printf("Detected r2z file, processing with mod installation...")
-
-		tempFile, err := os.CreateTemp("", "profile_*.r2z")
-		if err != nil {
-			return fmt.Errorf("failed to create temp file: %w", err)
-		}
-		defer os.Remove(tempFile.Name())
-		defer tempFile.Close()
-
-		_, err = tempFile.Write(buf)
-		if err != nil {
-			return fmt.Errorf("failed to write temp file: %w", err)
-		}
-		tempFile.Close()
+		logger().Info("Detected r2z file, installing mods")
 
-		err = extractAndInstallR2Z(tempFile.Name(), game, profileDir)
+		err = s.extractAndInstallR2Z(zipReader, game)
 		if err != nil {
 			return fmt.Errorf("failed to install r2z profile: %w", err)
 		}
 	} else {
-		log.Printf("Processing as regular ZIP file...")
+		logger().Info("Processing as regular ZIP file")
 
-		fullProfilePath := filepath.Join(profileDir, game.ProfileName)
+		fullProfilePath := filepath.Join(profileDir, GetProfileName(game))
 
-		err = os.MkdirAll(fullProfilePath, 0755)
+		err = s.fsys.MkdirAll(fullProfilePath, 0755)
 		if err != nil {
 			return fmt.Errorf("failed to create profile directory: %w", err)
 		}
 
-		log.Printf("Installing profile to: %s", fullProfilePath)
+		logger().Info("Installing profile", "path", fullProfilePath)
 
 		for _, f := range zipReader.File {
 			if f.FileInfo().IsDir() {
 				continue
 			}
 
-			destPath := filepath.Join(fullProfilePath, f.Name)
-
-			destDir := filepath.Dir(destPath)
-			err = os.MkdirAll(destDir, 0755)
+			destPath, err := safeJoin(fullProfilePath, f.Name)
 			if err != nil {
-				return fmt.Errorf("failed to create directory %s: %w", destDir, err)
+				return err
 			}
 
-			err := extractFileFromZip(f, destPath)
+			err = s.extractFileToPath(f, destPath)
 			if err != nil {
 				return fmt.Errorf("failed to extract %s: %w", f.Name, err)
 			}
@@ -110,43 +113,46 @@ func DownloadAndInstall(game internal.Game, targetDir string) error {
 
 		bepInExPath := filepath.Join(fullProfilePath, "BepInEx")
 		cachePath := filepath.Join(bepInExPath, "cache")
-		if err := os.RemoveAll(cachePath); err != nil && !os.IsNotExist(err) {
-			log.Printf("Warning: Failed to clean cache directory: %v", err)
+		if err := s.fsys.RemoveAll(cachePath); err != nil && !os.IsNotExist(err) {
+			logger().Warn("Failed to clean cache directory", "error", err)
 		}
 
 		logPath := filepath.Join(bepInExPath, "LogOutput.log")
-		if err := os.Remove(logPath); err != nil && !os.IsNotExist(err) {
-			log.Printf("Note: Could not remove log file: %v", err)
+		if err := s.fsys.Remove(logPath); err != nil && !os.IsNotExist(err) {
+			logger().Debug("Could not remove log file", "error", err)
+		}
+
+		if err := s.writeFileIndex(game, &FileIndex{}); err != nil {
+			logger().Warn("Failed to record installed files", "game", game.Name, "error", err)
 		}
 	}
 
-	err = SaveProfileVersion(game, targetDir)
+	err = s.SaveProfileVersion(game)
 	if err != nil {
-		log.Printf("Warning: Failed to save profile version file for %s: %v", game.Name, err)
+		logger().Warn("Failed to save profile version file", "game", game.Name, "error", err)
 	}
 
-	err = SaveProfileVersionInModsYML(game, targetDir)
+	err = s.SaveProfileVersionInModsYML(game)
 	if err != nil {
-		log.Printf("Warning: Failed to save profile version in mods.yml for %s: %v", game.Name, err)
+		logger().Warn("Failed to save profile version in mods.yml", "game", game.Name, "error", err)
 	}
 
-	log.Printf("Successfully installed profile for %s", game.Name)
+	err = s.recordConfigState(filepath.Join(profileDir, GetProfileName(game)))
+	if err != nil {
+		logger().Warn("Failed to record config state", "game", game.Name, "error", err)
+	}
+
+	logger().Info("Installed profile", "game", game.Name)
 	return nil
 }
 
-func extractAndInstallR2Z(r2zPath string, game internal.Game, targetDir string) error {
-	profileName := getProfileName(game)
-	profilePath := filepath.Join(targetDir, profileName)
-
-	log.Printf("Processing r2z file for profile: %s\n", profilePath)
+func (s *ProfileStore) extractAndInstallR2Z(reader *zip.Reader, game internal.Game) error {
+	profileName := GetProfileName(game)
+	profilePath := s.ProfilePath(game)
 
-	reader, err := zip.OpenReader(r2zPath)
-	if err != nil {
-		return fmt.Errorf("failed to open r2z file: %w", err)
-	}
-	defer reader.Close()
+	logger().Info("Processing r2z file", "profile", profilePath)
 
-	err = os.MkdirAll(profilePath, 0755)
+	err := s.fsys.MkdirAll(profilePath, 0755)
 	if err != nil {
 		return fmt.Errorf("failed to create profile directory: %w", err)
 	}
@@ -177,13 +183,26 @@ func extractAndInstallR2Z(r2zPath string, game internal.Game, targetDir string)
 		return fmt.Errorf("export.r2x not found in r2z file")
 	}
 
+	if game.Community == "" {
+		return fmt.Errorf("no community found for game %s", game.Name)
+	}
+
+	report := newInstallReport(game)
+	pending, err := s.resolveProfileMods(exportR2X, game, report)
+	if err != nil {
+		if reportErr := s.saveInstallReport(game, report); reportErr != nil {
+			logger().Warn("Could not save install report", "error", reportErr)
+		}
+		return err
+	}
+
 	bepInExPath := filepath.Join(profilePath, "BepInEx")
 	pluginsPath := filepath.Join(bepInExPath, "plugins")
 	configPath := filepath.Join(bepInExPath, "config")
 	corePath := filepath.Join(bepInExPath, "core")
 
 	for _, dir := range []string{bepInExPath, pluginsPath, configPath, corePath} {
-		err = os.MkdirAll(dir, 0755)
+		err = s.fsys.MkdirAll(dir, 0755)
 		if err != nil {
 			return fmt.Errorf("failed to create directory %s: %w", dir, err)
 		}
@@ -198,167 +217,150 @@ func extractAndInstallR2Z(r2zPath string, game internal.Game, targetDir string)
 			continue
 		}
 
-		destPath := filepath.Join(profilePath, file.Name)
-
-		destDir := filepath.Dir(destPath)
-		err = os.MkdirAll(destDir, 0755)
+		destPath, err := safeJoin(profilePath, installedConfigPath(file.Name))
 		if err != nil {
-			return fmt.Errorf("failed to create directory %s: %w", destDir, err)
+			return err
 		}
 
-		err = extractFileFromZip(file, destPath)
+		err = s.extractFileToPath(file, destPath)
 		if err != nil {
 			return fmt.Errorf("failed to extract %s: %w", file.Name, err)
 		}
 	}
 
-	log.Println("Cleaning BepInEx cache to prevent startup issues...")
+	logger().Info("Cleaning BepInEx cache to prevent startup issues")
 	cachePath := filepath.Join(bepInExPath, "cache")
-	if err := os.RemoveAll(cachePath); err != nil && !os.IsNotExist(err) {
-		log.Printf("Warning: Failed to clean cache directory: %v\n", err)
+	if err := s.fsys.RemoveAll(cachePath); err != nil && !os.IsNotExist(err) {
+		logger().Warn("Failed to clean cache directory", "error", err)
 	}
 
 	logPath := filepath.Join(bepInExPath, "LogOutput.log")
-	if err := os.Remove(logPath); err != nil && !os.IsNotExist(err) {
-		log.Printf("Note: Could not remove log file: %v\n", err)
+	if err := s.fsys.Remove(logPath); err != nil && !os.IsNotExist(err) {
+		logger().Debug("Could not remove log file", "error", err)
 	}
 
 	statePath := filepath.Join(profilePath, "_state")
-	err = os.MkdirAll(statePath, 0755)
+	err = s.fsys.MkdirAll(statePath, 0755)
 	if err != nil {
 		return fmt.Errorf("failed to create _state directory: %w", err)
 	}
 
 	stateFilePath := filepath.Join(statePath, "installation_state.yml")
 	stateContent := "currentState: []\n"
-	err = os.WriteFile(stateFilePath, []byte(stateContent), 0644)
+	err = s.fsys.WriteFile(stateFilePath, []byte(stateContent), 0644)
 	if err != nil {
 		return fmt.Errorf("failed to create installation_state.yml: %w", err)
 	}
 
-	log.Println("Downloading and installing mods from Thunderstore...")
+	logger().Info("Downloading and installing mods from Thunderstore")
 
-	if game.Community == "" {
-		return fmt.Errorf("no community found for game %s", game.Name)
+	index := &FileIndex{Mods: make(map[string]string)}
+	err = s.downloadAndInstallModsCompatible(pending, pluginsPath, profilePath, report, index)
+	if reportErr := s.saveInstallReport(game, report); reportErr != nil {
+		logger().Warn("Could not save install report", "error", reportErr)
 	}
-
-	community := game.Community
-
-	err = downloadAndInstallModsCompatible(exportR2X, pluginsPath, community, profilePath)
 	if err != nil {
 		return fmt.Errorf("failed to download and install mods: %w", err)
 	}
 
-	err = createModsYMLFromExport(exportR2X, profilePath)
+	err = s.createModsYMLFromExport(exportR2X, profilePath, game.Community)
 	if err != nil {
 		return fmt.Errorf("failed to create mods.yml: %w", err)
 	}
 
 	winhttpPath := filepath.Join(profilePath, "winhttp.dll")
-	if _, err := os.Stat(winhttpPath); os.IsNotExist(err) {
-		err = os.WriteFile(winhttpPath, []byte{}, 0644)
+	if _, err := s.fsys.Stat(winhttpPath); os.IsNotExist(err) {
+		err = s.fsys.WriteFile(winhttpPath, []byte{}, 0644)
 		if err != nil {
-			log.Printf("Warning: Could not create winhttp.dll placeholder: %v\n", err)
+			logger().Warn("Could not create winhttp.dll placeholder", "error", err)
 		}
 	}
 
-	log.Printf("Successfully installed profile: %s\n", profileName)
-	return nil
-}
-
-func downloadAndInstallModsCompatible(exportR2X *ExportFormatR2X, pluginsPath, community, profilePath string) error {
-	if exportR2X == nil {
-		return fmt.Errorf("export format is nil")
+	if err := s.writeFileIndex(game, index); err != nil {
+		logger().Warn("Failed to record installed files", "game", game.Name, "error", err)
 	}
 
-	enabledMods := make(map[string]bool)
-	for _, mod := range exportR2X.Mods {
-		if mod.Enabled {
-			modKey := fmt.Sprintf("%s-%d.%d.%d", mod.Name, mod.Version.Major, mod.Version.Minor, mod.Version.Patch)
-			enabledMods[modKey] = true
-		}
-	}
+	logger().Info("Installed profile", "profile", profileName)
+	return nil
+}
 
-	log.Printf("Installing %d enabled mods...\n", len(enabledMods))
+type pendingMod struct {
+	key      string
+	result   int
+	resolved *resolvedMod
+}
 
-	installedMods := make(map[string]bool)
+func (s *ProfileStore) resolveProfileMods(exportR2X *ExportFormatR2X, game internal.Game, report *InstallReport) ([]pendingMod, error) {
+	var pending []pendingMod
+	var deprecated []error
+	seen := make(map[string]bool)
 
 	for _, mod := range exportR2X.Mods {
 		if !mod.Enabled {
 			continue
 		}
 
-		modKey := fmt.Sprintf("%s-%d.%d.%d", mod.Name, mod.Version.Major, mod.Version.Minor, mod.Version.Patch)
-
-		if installedMods[modKey] {
+		version := fmt.Sprintf("%d.%d.%d", mod.Version.Major, mod.Version.Minor, mod.Version.Patch)
+		modKey := mod.Name + "-" + version
+		if seen[modKey] {
 			continue
 		}
+		seen[modKey] = true
 
-		err := installModWithDependencies(mod, pluginsPath, community, installedMods, enabledMods)
+		result := ModInstallResult{Name: mod.Name, RequestedVersion: version}
+		resolved, err := s.resolveMod(mod.Name, version, game.Community)
+		result.Strategy = resolved.Strategy
+		result.Health = resolved.Health
 		if err != nil {
-			log.Printf("Warning: Failed to install mod %s: %v\n", modKey, err)
+			result.Error = err.Error()
+			report.Mods = append(report.Mods, result)
+			logger().Warn("Failed to resolve mod", "mod", modKey, "error", err)
 			continue
 		}
 
-		installedMods[modKey] = true
-		log.Printf("✓ Installed mod: %s\n", modKey)
-	}
-
-	essentialFiles := map[string][]string{
-		"BepInEx.Preloader.dll": {
-			filepath.Join(profilePath, "BepInEx", "core", "BepInEx.Preloader.dll"),
-			filepath.Join(profilePath, "config", "BepInEx.Preloader.dll"),
-		},
-		"BepInEx.cfg": {
-			filepath.Join(profilePath, "config", "BepInEx.cfg"),
-			filepath.Join(profilePath, "BepInEx", "config", "BepInEx.cfg"),
-			filepath.Join(profilePath, "BepInEx.cfg"),
-		},
-		"doorstop_config.ini": {
-			filepath.Join(profilePath, "doorstop_config.ini"),
-			filepath.Join(profilePath, "config", "doorstop_config.ini"),
-		},
-		"installation_state.yml": {
-			filepath.Join(profilePath, "_state", "installation_state.yml"),
-		},
-	}
-
-	for fileName, possiblePaths := range essentialFiles {
-		found := false
-		for _, checkPath := range possiblePaths {
-			if _, err := os.Stat(checkPath); err == nil {
-				log.Printf("✓ Found essential file: %s at %s\n", fileName, checkPath)
-				found = true
-				break
-			}
-		}
-		if !found {
-			log.Printf("✗ Missing essential file: %s (checked: %v)\n", fileName, possiblePaths)
-			return fmt.Errorf("essential file missing after installation: %s", fileName)
+		if game.FailOnDeprecated && thunderstore.HasFlag(resolved.Health, thunderstore.HealthDeprecated) {
+			deprecated = append(deprecated, &thunderstore.PackageError{Community: game.Community, FullName: mod.Name, Err: thunderstore.ErrDeprecated})
 		}
+
+		report.Mods = append(report.Mods, result)
+		pending = append(pending, pendingMod{key: modKey, result: len(report.Mods) - 1, resolved: resolved})
 	}
 
-	log.Printf("✓ Successfully installed %d mods with r2modman compatibility\n", len(installedMods))
-	return nil
+	if len(deprecated) > 0 {
+		return nil, fmt.Errorf("profile contains deprecated packages: %w", errors.Join(deprecated...))
+	}
+	return pending, nil
 }
 
-func installModWithDependencies(mod ModInfo, pluginsPath, community string, installedMods, enabledMods map[string]bool) error {
-	modKey := fmt.Sprintf("%s-%d.%d.%d", mod.Name, mod.Version.Major, mod.Version.Minor, mod.Version.Patch)
+func (s *ProfileStore) downloadAndInstallModsCompatible(pending []pendingMod, pluginsPath, profilePath string, report *InstallReport, index *FileIndex) error {
+	logger().Info("Installing enabled mods", "count", len(pending))
 
-	if installedMods[modKey] {
-		return nil
+	installed := 0
+	for _, mod := range pending {
+		result := &report.Mods[mod.result]
+		written, err := s.installResolvedMod(mod.resolved, pluginsPath)
+		if err != nil {
+			result.Error = err.Error()
+			logger().Warn("Failed to install mod", "mod", mod.key, "error", err)
+			continue
+		}
+		index.addMod(profilePath, mod.resolved, written)
+
+		result.InstalledVersion = mod.resolved.Version.VersionNumber
+		installed++
+		logger().Info("Installed mod", "mod", mod.key)
 	}
 
-	err := downloadAndExtractMod(mod, pluginsPath, community)
-	if err != nil {
-		return fmt.Errorf("failed to download mod %s: %w", modKey, err)
+	if missing := s.missingEssentials(profilePath, true); len(missing) > 0 {
+		logger().Error("Missing essential files", "files", missing)
+		return fmt.Errorf("essential file missing after installation: %s", missing[0])
 	}
 
-	installedMods[modKey] = true
+	logger().Info("Installed mods with r2modman compatibility", "count", installed)
 	return nil
 }
 
-func createModsYMLFromExport(exportR2X *ExportFormatR2X, profilePath string) error {
+func (s *ProfileStore) createModsYMLFromExport(exportR2X *ExportFormatR2X, profilePath, community string) error {
 	var modsYML ModsYML
 	currentTime := time.Now().Unix() * 1000
 
@@ -375,7 +377,7 @@ func createModsYMLFromExport(exportR2X *ExportFormatR2X, profilePath string) err
 			ManifestVersion:      1,
 			Name:                 mod.Name,
 			AuthorName:           authorName,
-			WebsiteURL:           fmt.Sprintf("https://thunderstore.io/c/repo/p/%s/", strings.Replace(mod.Name, "-", "/", 1)),
+			WebsiteURL:           s.client.PackageURL(community, mod.Name),
 			DisplayName:          displayName,
 			Description:          "Mod installed by ModHelper",
 			GameVersion:          "0",
@@ -404,11 +406,11 @@ func createModsYMLFromExport(exportR2X *ExportFormatR2X, profilePath string) err
 	}
 
 	modsYMLPath := filepath.Join(profilePath, "mods.yml")
-	err = os.WriteFile(modsYMLPath, data, 0644)
+	err = s.fsys.WriteFile(modsYMLPath, data, 0644)
 	if err != nil {
 		return fmt.Errorf("failed to write mods.yml: %w", err)
 	}
 
-	log.Printf("Created mods.yml with %d mods\n", len(modsYML))
+	logger().Info("Created mods.yml", "mods", len(modsYML))
 	return nil
 }
@@ -1,26 +1,36 @@
 package profile
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
-	"log"
+	"io"
 	"net/http"
+	"os"
 	"strings"
 	"time"
 
 	"github.com/ur-wesley/modhelper/internal"
 )
 
-func FetchGames(manifestURL string) ([]internal.Game, error) {
+func FetchGames(manifestURL string, timeout time.Duration) ([]internal.Game, error) {
+	manifest, err := FetchManifest(manifestURL, timeout)
+	if err != nil {
+		return nil, err
+	}
+	return manifest.Games, nil
+}
+
+func FetchManifest(manifestURL string, timeout time.Duration) (*internal.Manifest, error) {
 	separator := "?"
 	if strings.Contains(manifestURL, "?") {
 		separator = "&"
 	}
 	timestampedURL := fmt.Sprintf("%s%st=%d", manifestURL, separator, time.Now().Unix())
 
-	log.Printf("Fetching manifest from: %s", timestampedURL)
+	logger().Info("Fetching manifest", "url", timestampedURL)
 
-	client := &http.Client{Timeout: 10 * time.Second}
+	client := &http.Client{Timeout: timeout}
 	resp, err := client.Get(timestampedURL)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch manifest: %w", err)
@@ -31,10 +41,47 @@ func FetchGames(manifestURL string) ([]internal.Game, error) {
 		return nil, fmt.Errorf("manifest request failed with status: %s", resp.Status)
 	}
 
-	var games []internal.Game
-	if err := json.NewDecoder(resp.Body).Decode(&games); err != nil {
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+
+	return ParseManifest(data)
+}
+
+func LoadManifestFile(path string) (*internal.Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest file: %w", err)
+	}
+	return ParseManifest(data)
+}
+
+func ParseManifest(data []byte) (*internal.Manifest, error) {
+	data = bytes.TrimSpace(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")))
+	if len(data) == 0 {
+		return nil, fmt.Errorf("failed to parse manifest: empty document")
+	}
+
+	if data[0] == '[' {
+		var games []internal.Game
+		if err := json.Unmarshal(data, &games); err != nil {
+			return nil, fmt.Errorf("failed to parse manifest: %w", err)
+		}
+		return &internal.Manifest{SchemaVersion: 1, Games: games}, nil
+	}
+
+	var manifest internal.Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
 		return nil, fmt.Errorf("failed to parse manifest: %w", err)
 	}
 
-	return games, nil
+	if manifest.SchemaVersion == 0 {
+		return nil, &SchemaError{Version: 0}
+	}
+	if manifest.SchemaVersion > internal.ManifestSchemaVersion {
+		return nil, &SchemaError{Version: manifest.SchemaVersion}
+	}
+
+	return &manifest, nil
 }
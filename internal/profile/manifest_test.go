@@ -0,0 +1,30 @@
+package profile
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestFetchManifestUsesTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/slow.json" {
+			time.Sleep(200 * time.Millisecond)
+		}
+		w.Write([]byte(`{"schemaVersion":1,"games":[{"name":"Test Game","url":"https://example.com/profile.r2z","version":"1.0.0"}]}`))
+	}))
+	defer server.Close()
+
+	if _, err := FetchManifest(server.URL+"/slow.json", 50*time.Millisecond); err == nil {
+		t.Error("FetchManifest() succeeded past the configured timeout")
+	}
+
+	manifest, err := FetchManifest(server.URL+"/manifest.json", time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(manifest.Games) != 1 || manifest.Games[0].Name != "Test Game" {
+		t.Errorf("games = %+v", manifest.Games)
+	}
+}
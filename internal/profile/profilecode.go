@@ -0,0 +1,108 @@
+package profile
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/ur-wesley/modhelper/internal"
+)
+
+const profileCodePrefix = "#r2modman"
+
+var profileCodePattern = regexp.MustCompile(`^[A-Za-z0-9-]{1,64}$`)
+
+func (e *ProfileExport) ProfileCodePayload() string {
+	return profileCodePrefix + "\n" + base64.StdEncoding.EncodeToString(e.Archive)
+}
+
+func (s *ProfileStore) CreateProfileCode(export *ProfileExport) (string, error) {
+	code, err := s.client.CreateLegacyProfile([]byte(export.ProfileCodePayload()))
+	if err != nil {
+		return "", err
+	}
+
+	logger().Info("Created profile code", "code", code, "profile", export.ProfileName)
+	return code, nil
+}
+
+func (s *ProfileStore) FetchProfileCode(code string) ([]byte, error) {
+	code = strings.TrimSpace(code)
+	if !profileCodePattern.MatchString(code) {
+		return nil, fmt.Errorf("invalid profile code %q", code)
+	}
+
+	payload, err := s.client.LegacyProfile(code)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeProfileCodePayload(payload)
+}
+
+func decodeProfileCodePayload(payload []byte) ([]byte, error) {
+	text := strings.TrimSpace(strings.TrimPrefix(string(payload), "\xef\xbb\xbf"))
+	if !strings.HasPrefix(text, profileCodePrefix) {
+		return nil, fmt.Errorf("profile code data is not an r2modman profile")
+	}
+	text = strings.Join(strings.Fields(strings.TrimPrefix(text, profileCodePrefix)), "")
+
+	archive, err := base64.StdEncoding.DecodeString(text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode profile code data: %w", err)
+	}
+
+	zipReader, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read profile archive: %w", err)
+	}
+	for _, file := range zipReader.File {
+		if file.Name == "export.r2x" {
+			return archive, nil
+		}
+	}
+	return nil, fmt.Errorf("export.r2x not found in profile code data")
+}
+
+func (s *ProfileStore) ImportProfileCode(game internal.Game, code string) (string, error) {
+	archive, err := s.FetchProfileCode(code)
+	if err != nil {
+		return "", err
+	}
+
+	zipReader, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		return "", fmt.Errorf("failed to read profile archive: %w", err)
+	}
+
+	if game.ProfileName == "" {
+		for _, file := range zipReader.File {
+			if file.Name != "export.r2x" {
+				continue
+			}
+			exportR2X, err := parseExportR2X(file)
+			if err != nil {
+				return "", fmt.Errorf("failed to parse export.r2x: %w", err)
+			}
+			game.ProfileName = exportR2X.ProfileName
+		}
+	}
+	game.ProfileName = strings.TrimSpace(game.ProfileName)
+	if game.ProfileName == "" || strings.ContainsAny(game.ProfileName, `/\:*?"<>|`) || strings.Trim(game.ProfileName, ".") == "" {
+		return "", fmt.Errorf("invalid profile name %q", game.ProfileName)
+	}
+
+	if err := s.extractAndInstallR2Z(zipReader, game); err != nil {
+		return "", fmt.Errorf("failed to install profile code %s: %w", code, err)
+	}
+
+	if err := s.recordConfigState(s.ProfilePath(game)); err != nil {
+		logger().Warn("Failed to record config state", "game", game.Name, "error", err)
+	}
+
+	logger().Info("Imported profile code", "code", code, "profile", game.ProfileName, "game", game.Name)
+	return game.ProfileName, nil
+}
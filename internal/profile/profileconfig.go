@@ -0,0 +1,73 @@
+package profile
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/ur-wesley/modhelper/internal"
+)
+
+func (s *ProfileStore) ListProfileConfigs(game internal.Game) ([]string, error) {
+	files, err := s.listConfigFiles(s.ProfilePath(game))
+	if err != nil {
+		return nil, err
+	}
+
+	var configs []string
+	for _, file := range files {
+		if strings.EqualFold(filepath.Ext(file), ".cfg") {
+			configs = append(configs, file)
+		}
+	}
+	return configs, nil
+}
+
+func (s *ProfileStore) LoadProfileConfig(game internal.Game, relPath string) (*CfgFile, error) {
+	path, err := resolveProfileConfigPath(s.ProfilePath(game), relPath)
+	if err != nil {
+		return nil, err
+	}
+	return s.loadCfg(path)
+}
+
+func (s *ProfileStore) LoadProfileConfigDefaults(game internal.Game, relPath string) (*CfgFile, error) {
+	path, err := resolveProfileConfigPath(filepath.Join(s.ProfilePath(game), configBaselineDir), relPath)
+	if err != nil {
+		return nil, err
+	}
+	return s.loadCfg(path)
+}
+
+func (s *ProfileStore) SaveProfileConfig(game internal.Game, relPath string, file *CfgFile) error {
+	path, err := resolveProfileConfigPath(s.ProfilePath(game), relPath)
+	if err != nil {
+		return err
+	}
+
+	if err := s.fsys.WriteFile(path, file.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to save %s: %w", relPath, err)
+	}
+
+	logger().Info("Saved config", "path", relPath, "game", game.Name)
+	return nil
+}
+
+func (s *ProfileStore) loadCfg(path string) (*CfgFile, error) {
+	data, err := s.fsys.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCfg(data)
+}
+
+func resolveProfileConfigPath(root, relPath string) (string, error) {
+	cleaned := filepath.Clean(filepath.FromSlash(relPath))
+	if filepath.IsAbs(cleaned) || cleaned == ".." || strings.HasPrefix(cleaned, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("config path %s is outside the profile", relPath)
+	}
+	if !isConfigPath(filepath.ToSlash(cleaned)) {
+		return "", fmt.Errorf("%s is not a config file", relPath)
+	}
+	return filepath.Join(root, cleaned), nil
+}
@@ -0,0 +1,176 @@
+package profile
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/ur-wesley/modhelper/internal"
+)
+
+func (s *ProfileStore) Repair(game internal.Game) (*VerifyResult, error) {
+	result, err := s.Verify(game)
+	if err != nil {
+		return nil, err
+	}
+	if result.OK() {
+		return result, nil
+	}
+
+	profilePath := s.ProfilePath(game)
+
+	index, err := s.loadFileIndex(profilePath)
+	if err != nil {
+		index = &FileIndex{}
+	}
+
+	mods, err := s.readProfileMods(profilePath)
+	if err != nil {
+		logger().Warn("Could not read mods", "game", game.Name, "error", err)
+		mods = map[string]string{}
+	}
+
+	repairMods := make(map[string]bool)
+	var archivePaths []string
+	for _, problem := range result.Problems {
+		switch {
+		case problem.Mod != "":
+			repairMods[problem.Mod] = true
+		case problem.Kind == ProblemEssentialMissing && problem.Path == stateEssential:
+			statePath := filepath.Join(profilePath, "_state")
+			if err := s.fsys.MkdirAll(statePath, 0755); err == nil {
+				err = s.fsys.WriteFile(filepath.Join(statePath, "installation_state.yml"), []byte("currentState: []\n"), 0644)
+			}
+			if err != nil {
+				logger().Warn("Could not restore installation_state.yml", "game", game.Name, "error", err)
+			}
+		case problem.Kind == ProblemEssentialMissing:
+			if pack := bepInExPackName(mods); pack != "" {
+				repairMods[pack] = true
+			}
+			for _, candidate := range loaderEssentials(".")[problem.Path] {
+				archivePaths = append(archivePaths, filepath.ToSlash(filepath.Clean(candidate)))
+			}
+		default:
+			archivePaths = append(archivePaths, problem.Path)
+		}
+	}
+
+	var repairErrs []error
+
+	if len(archivePaths) > 0 {
+		restored, err := s.restoreFromArchive(game, archivePaths)
+		if err != nil {
+			repairErrs = append(repairErrs, err)
+		}
+		for _, rel := range restored {
+			s.reindexFile(profilePath, index, rel, index.owner(rel))
+		}
+	}
+
+	pluginsPath := filepath.Join(profilePath, "BepInEx", "plugins")
+	for name := range repairMods {
+		version, ok := mods[name]
+		if !ok {
+			version = index.Mods[name]
+		}
+
+		resolved, err := s.resolveMod(name, version, game.Community)
+		if err != nil {
+			repairErrs = append(repairErrs, err)
+			continue
+		}
+		written, err := s.installResolvedMod(resolved, pluginsPath)
+		if err != nil {
+			repairErrs = append(repairErrs, err)
+			continue
+		}
+
+		index.addMod(profilePath, resolved, written)
+		for _, file := range written {
+			if rel, err := filepath.Rel(profilePath, file); err == nil {
+				s.reindexFile(profilePath, index, filepath.ToSlash(rel), name)
+			}
+		}
+		logger().Info("Repaired mod", "mod", name, "version", resolved.Version.VersionNumber, "game", game.Name)
+	}
+
+	if len(index.Files) > 0 || index.Mods != nil {
+		if err := s.saveFileIndex(profilePath, index); err != nil {
+			repairErrs = append(repairErrs, err)
+		}
+	}
+
+	result, err = s.Verify(game)
+	if err != nil {
+		return nil, err
+	}
+	return result, errors.Join(repairErrs...)
+}
+
+func (s *ProfileStore) restoreFromArchive(game internal.Game, paths []string) ([]string, error) {
+	installedVersion, err := s.GetInstalledProfileVersion(game)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read installed profile version: %w", err)
+	}
+	if compareProfileVersions(installedVersion, game.Version) != VersionEqual {
+		return nil, fmt.Errorf("installed profile version %s differs from manifest version %s, reinstall the profile to restore files", installedVersion, game.Version)
+	}
+
+	buf, err := s.downloadProfileArchive(game)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download profile to restore files: %w", err)
+	}
+
+	reader, err := zip.NewReader(bytes.NewReader(buf), int64(len(buf)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ZIP data: %w", err)
+	}
+
+	wanted := make(map[string]bool)
+	for _, path := range paths {
+		wanted[path] = true
+	}
+
+	profilePath := s.ProfilePath(game)
+	var restored []string
+	for _, file := range reader.File {
+		name := installedConfigPath(file.Name)
+		if !wanted[name] || file.FileInfo().IsDir() {
+			continue
+		}
+
+		destPath, err := safeJoin(profilePath, name)
+		if err != nil {
+			return restored, err
+		}
+		if err := s.extractFileToPath(file, destPath); err != nil {
+			return restored, fmt.Errorf("failed to restore %s: %w", name, err)
+		}
+		restored = append(restored, name)
+		logger().Info("Restored file from profile archive", "file", name)
+	}
+
+	return restored, nil
+}
+
+func (s *ProfileStore) reindexFile(profilePath string, index *FileIndex, rel, mod string) {
+	file := IndexedFile{Path: rel, Mod: mod}
+	if err := s.hashIndexedFile(profilePath, &file); err != nil {
+		logger().Warn("Could not record file", "file", rel, "error", err)
+		return
+	}
+	index.put(file)
+}
+
+func bepInExPackName(mods map[string]string) string {
+	for name := range mods {
+		if strings.Contains(strings.ToLower(name), "bepinexpack") {
+			return name
+		}
+	}
+	return ""
+}
@@ -0,0 +1,80 @@
+package profile
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func serveTestArchive(t *testing.T, files map[string]string) (string, *int) {
+	t.Helper()
+
+	archive := buildTestArchiveBytes(t, files)
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write(archive)
+	}))
+	t.Cleanup(server.Close)
+	return server.URL + "/profile.r2z", &requests
+}
+
+func TestRestoreFromArchive(t *testing.T) {
+	url, requests := serveTestArchive(t, map[string]string{
+		"export.r2x":      "profileName: Test\nmods: []\n",
+		"config/Mod.cfg":  "[General]\nValue = 1\n",
+		"config/Skip.cfg": "[General]\n",
+	})
+
+	tests := []struct {
+		name         string
+		installed    string
+		manifest     string
+		wantRestored []string
+		wantErr      bool
+		wantRequests int
+	}{
+		{
+			name:         "same version",
+			installed:    "1.0.0",
+			manifest:     "1.0.0",
+			wantRestored: []string{"BepInEx/config/Mod.cfg"},
+			wantRequests: 1,
+		},
+		{
+			name:      "newer manifest version",
+			installed: "1.0.0",
+			manifest:  "1.1.0",
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			*requests = 0
+			store := newTestStore(t)
+			installTestProfile(t, store, testGame(tt.installed), true)
+
+			game := testGame(tt.manifest)
+			game.URL = url
+			restored, err := store.restoreFromArchive(game, []string{"BepInEx/config/Mod.cfg"})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("restoreFromArchive() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !slices.Equal(restored, tt.wantRestored) {
+				t.Errorf("restored = %v, want %v", restored, tt.wantRestored)
+			}
+			if *requests != tt.wantRequests {
+				t.Errorf("downloads = %d, want %d", *requests, tt.wantRequests)
+			}
+
+			for _, rel := range tt.wantRestored {
+				if _, err := store.fsys.Stat(filepath.Join(store.ProfilePath(game), filepath.FromSlash(rel))); err != nil {
+					t.Errorf("%s was not restored: %v", rel, err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package profile
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/ur-wesley/modhelper/internal"
+	"github.com/ur-wesley/modhelper/internal/thunderstore"
+)
+
+const installReportFile = ".install_report.json"
+
+type InstallReport struct {
+	Game        string             `json:"game"`
+	ProfileName string             `json:"profileName"`
+	Version     string             `json:"version,omitempty"`
+	Community   string             `json:"community"`
+	InstalledAt time.Time          `json:"installedAt"`
+	Mods        []ModInstallResult `json:"mods"`
+}
+
+type ModInstallResult struct {
+	Name             string                    `json:"name"`
+	RequestedVersion string                    `json:"requestedVersion"`
+	InstalledVersion string                    `json:"installedVersion,omitempty"`
+	Strategy         thunderstore.Strategy     `json:"strategy,omitempty"`
+	Health           []thunderstore.HealthFlag `json:"health,omitempty"`
+	Error            string                    `json:"error,omitempty"`
+}
+
+func newInstallReport(game internal.Game) *InstallReport {
+	return &InstallReport{
+		Game:        game.Name,
+		ProfileName: GetProfileName(game),
+		Version:     game.Version,
+		Community:   game.Community,
+		InstalledAt: time.Now(),
+	}
+}
+
+func (r *InstallReport) Failed() []ModInstallResult {
+	var failed []ModInstallResult
+	for _, mod := range r.Mods {
+		if mod.Error != "" {
+			failed = append(failed, mod)
+		}
+	}
+	return failed
+}
+
+func (r *InstallReport) Flagged() []ModInstallResult {
+	var flagged []ModInstallResult
+	for _, mod := range r.Mods {
+		if len(mod.Health) > 0 {
+			flagged = append(flagged, mod)
+		}
+	}
+	return flagged
+}
+
+func (r *InstallReport) StrategyCounts() map[thunderstore.Strategy]int {
+	counts := make(map[thunderstore.Strategy]int)
+	for _, mod := range r.Mods {
+		if mod.Strategy != "" {
+			counts[mod.Strategy]++
+		}
+	}
+	return counts
+}
+
+func (s *ProfileStore) saveInstallReport(game internal.Game, report *InstallReport) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal install report: %w", err)
+	}
+
+	reportPath := filepath.Join(s.ProfilePath(game), installReportFile)
+	if err := s.fsys.WriteFile(reportPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write install report: %w", err)
+	}
+
+	logger().Info("Install report", "game", game.Name, "mods", len(report.Mods), "failed", len(report.Failed()), "lookups", report.StrategyCounts())
+	return nil
+}
+
+func (s *ProfileStore) LoadInstallReport(game internal.Game) (*InstallReport, error) {
+	data, err := s.fsys.ReadFile(filepath.Join(s.ProfilePath(game), installReportFile))
+	if err != nil {
+		return nil, err
+	}
+
+	var report InstallReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("failed to parse install report: %w", err)
+	}
+	return &report, nil
+}
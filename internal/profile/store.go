@@ -0,0 +1,96 @@
+package profile
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/ur-wesley/modhelper/internal"
+	"github.com/ur-wesley/modhelper/internal/config"
+	"github.com/ur-wesley/modhelper/internal/logging"
+	"github.com/ur-wesley/modhelper/internal/r2modman"
+	"github.com/ur-wesley/modhelper/internal/thunderstore"
+)
+
+func logger() *slog.Logger {
+	return logging.For("profile")
+}
+
+type ProfileStore struct {
+	root    string
+	manager r2modman.Manager
+	fsys    FS
+	client  *thunderstore.Client
+
+	statusMu   sync.Mutex
+	incomplete map[string]bool
+}
+
+func NewProfileStore(cfg *internal.Config) *ProfileStore {
+	store := NewProfileStoreAt(configuredStoreLayout(cfg))
+	store.client = thunderstore.NewClient(config.GetThunderstoreURL(cfg))
+	store.client.HTTPClient.Timeout = config.GetHTTPTimeout(cfg)
+	store.client.Retry.MaxAttempts = config.GetRetryAttempts(cfg)
+	return store
+}
+
+func configuredStoreLayout(cfg *internal.Config) (string, r2modman.Manager) {
+	manager := r2modman.Preferred()
+
+	root := ""
+	if cfg != nil {
+		root = filepath.Clean(strings.TrimSpace(cfg.TargetDir))
+	}
+	if root == "" || root == "." {
+		return manager.DataDir, manager
+	}
+
+	for _, detected := range r2modman.Detect() {
+		if sameDir(detected.DataDir, root) {
+			return root, detected
+		}
+	}
+
+	return root, r2modman.Manager{Kind: r2modman.KindR2Modman, Name: "r2modman"}
+}
+
+func NewProfileStoreAt(root string, manager r2modman.Manager) *ProfileStore {
+	return NewProfileStoreWithFS(root, manager, OSFS{})
+}
+
+func NewProfileStoreWithFS(root string, manager r2modman.Manager, fsys FS) *ProfileStore {
+	manager.DataDir = root
+	logger().Info("Using profile store", "root", root, "layout", manager.Kind)
+	return &ProfileStore{root: root, manager: manager, fsys: fsys, client: thunderstore.NewClient(thunderstore.DefaultBaseURL)}
+}
+
+func (s *ProfileStore) FS() FS {
+	return s.fsys
+}
+
+func (s *ProfileStore) Client() *thunderstore.Client {
+	return s.client
+}
+
+func (s *ProfileStore) SetClient(client *thunderstore.Client) {
+	s.client = client
+}
+
+func (s *ProfileStore) Root() string {
+	return s.root
+}
+
+func (s *ProfileStore) GameDir(game internal.Game) string {
+	return s.manager.ProfilesDir(game)
+}
+
+func (s *ProfileStore) ProfilePath(game internal.Game) string {
+	return filepath.Join(s.GameDir(game), GetProfileName(game))
+}
+
+func sameDir(a, b string) bool {
+	a, b = filepath.Clean(a), filepath.Clean(b)
+	return a == b || strings.EqualFold(a, b) && os.PathSeparator == '\\'
+}
@@ -0,0 +1,159 @@
+package profile
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/ur-wesley/modhelper/internal/thunderstore"
+)
+
+type fakePackageAPI struct {
+	packageStatuses []int
+	versions        map[string]bool
+	requests        map[string]int
+}
+
+func (f *fakePackageAPI) serve(t *testing.T) *thunderstore.Client {
+	t.Helper()
+
+	f.requests = make(map[string]int)
+	mux := http.NewServeMux()
+	version := func(number string) map[string]any {
+		return map[string]any{
+			"namespace":      "Owner",
+			"name":           "Mod",
+			"full_name":      "Owner-Mod-" + number,
+			"version_number": number,
+			"download_url":   "https://example.com/Owner-Mod-" + number + ".zip",
+		}
+	}
+
+	mux.HandleFunc("/api/experimental/package/Owner/Mod/", func(w http.ResponseWriter, r *http.Request) {
+		f.requests["package"]++
+		if attempt := f.requests["package"]; attempt <= len(f.packageStatuses) && f.packageStatuses[attempt-1] != http.StatusOK {
+			w.WriteHeader(f.packageStatuses[attempt-1])
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{
+			"namespace": "Owner",
+			"name":      "Mod",
+			"full_name": "Owner-Mod",
+			"owner":     "Owner",
+			"latest":    version("2.0.0"),
+		})
+	})
+	mux.HandleFunc("/api/experimental/package/Owner/Mod/{version}/", func(w http.ResponseWriter, r *http.Request) {
+		f.requests["version"]++
+		if !f.versions[r.PathValue("version")] {
+			http.NotFound(w, r)
+			return
+		}
+		json.NewEncoder(w).Encode(version(r.PathValue("version")))
+	})
+	mux.HandleFunc("/c/test/api/v1/package/", func(w http.ResponseWriter, r *http.Request) {
+		f.requests["index"]++
+		w.Write([]byte("[]"))
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := thunderstore.NewClient(server.URL)
+	client.Limiter = thunderstore.NewRateLimiter(0)
+	client.Retry = thunderstore.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 10 * time.Millisecond}
+	return client
+}
+
+func TestResolveMod(t *testing.T) {
+	tests := []struct {
+		name         string
+		api          fakePackageAPI
+		fullName     string
+		version      string
+		wantErr      error
+		wantVersion  string
+		wantStrategy thunderstore.Strategy
+		wantHealth   []thunderstore.HealthFlag
+		wantRequests map[string]int
+	}{
+		{
+			name:         "latest from package API",
+			fullName:     "Owner-Mod",
+			version:      "2.0.0",
+			wantVersion:  "2.0.0",
+			wantStrategy: thunderstore.StrategyPackageAPI,
+			wantRequests: map[string]int{"package": 1},
+		},
+		{
+			name:         "older version falls back to version API",
+			api:          fakePackageAPI{versions: map[string]bool{"1.0.0": true}},
+			fullName:     "Owner-Mod",
+			version:      "1.0.0",
+			wantVersion:  "1.0.0",
+			wantStrategy: thunderstore.StrategyVersionAPI,
+			wantRequests: map[string]int{"package": 1, "version": 1},
+		},
+		{
+			name:         "removed version",
+			fullName:     "Owner-Mod",
+			version:      "1.0.0",
+			wantVersion:  "2.0.0",
+			wantStrategy: thunderstore.StrategyPackageAPI,
+			wantHealth:   []thunderstore.HealthFlag{thunderstore.HealthVersionRemoved},
+			wantRequests: map[string]int{"package": 1, "version": 1},
+		},
+		{
+			name:         "missing package",
+			fullName:     "Owner-Missing",
+			version:      "1.0.0",
+			wantErr:      thunderstore.ErrNotFound,
+			wantStrategy: thunderstore.StrategyBulkIndex,
+			wantHealth:   []thunderstore.HealthFlag{thunderstore.HealthMissing},
+			wantRequests: map[string]int{"index": 1},
+		},
+		{
+			name:         "rate limited then success",
+			api:          fakePackageAPI{packageStatuses: []int{http.StatusTooManyRequests, http.StatusOK}},
+			fullName:     "Owner-Mod",
+			version:      "2.0.0",
+			wantVersion:  "2.0.0",
+			wantStrategy: thunderstore.StrategyPackageAPI,
+			wantRequests: map[string]int{"package": 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newTestStore(t)
+			store.SetClient(tt.api.serve(t))
+
+			resolved, err := store.resolveMod(tt.fullName, tt.version, "test")
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("resolveMod() error = %v, want %v", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Fatalf("resolveMod() error = %v", err)
+			} else if resolved.Version.VersionNumber != tt.wantVersion {
+				t.Errorf("version = %s, want %s", resolved.Version.VersionNumber, tt.wantVersion)
+			}
+
+			if resolved.Strategy != tt.wantStrategy {
+				t.Errorf("strategy = %s, want %s", resolved.Strategy, tt.wantStrategy)
+			}
+			if !slices.Equal(resolved.Health, tt.wantHealth) {
+				t.Errorf("health = %v, want %v", resolved.Health, tt.wantHealth)
+			}
+			for endpoint, want := range tt.wantRequests {
+				if got := tt.api.requests[endpoint]; got != want {
+					t.Errorf("%s requests = %d, want %d", endpoint, got, want)
+				}
+			}
+		})
+	}
+}
@@ -1,31 +1,5 @@
 package profile
 
-import "sync"
-
-var (
-	packageCache = make(map[string]*ThunderstorePackage)
-	cacheMutex   sync.RWMutex
-)
-
-type ThunderstorePackage struct {
-	FullName     string                       `json:"full_name"`
-	Name         string                       `json:"name"`
-	Owner        string                       `json:"owner"`
-	Versions     []ThunderstorePackageVersion `json:"versions"`
-	PackageURL   string                       `json:"package_url"`
-	IsDeprecated bool                         `json:"is_deprecated"`
-	Latest       *ThunderstorePackageVersion  `json:"-"`
-}
-
-type ThunderstorePackageVersion struct {
-	VersionNumber string   `json:"version_number"`
-	DownloadURL   string   `json:"download_url"`
-	Dependencies  []string `json:"dependencies"`
-	FileSize      int64    `json:"file_size"`
-	FullName      string   `json:"full_name"`
-	Description   string   `json:"description"`
-}
-
 type ThunderstoreManifest struct {
 	Name          string   `json:"name"`
 	VersionNumber string   `json:"version_number"`
@@ -115,10 +89,22 @@ type ProfileVersion struct {
 	Version string `json:"version"`
 }
 
+type VersionChange int
+
+const (
+	VersionEqual VersionChange = iota
+	VersionUpdate
+	VersionDowngrade
+)
+
 type ProfileStatus struct {
-	Installed    bool
-	UpToDate     bool
-	HasUpdate    bool
-	InstallError error
-	VersionError error
+	Installed         bool
+	UpToDate          bool
+	HasUpdate         bool
+	HasDowngrade      bool
+	ReinstallRequired bool
+	Incomplete        bool
+	InstalledVersion  string
+	InstallError      error
+	VersionError      error
 }
@@ -2,111 +2,173 @@ package profile
 
 import (
 	"fmt"
-	"log"
 	"os"
 	"path/filepath"
-	"strings"
 
 	"github.com/ur-wesley/modhelper/internal"
-	"github.com/ur-wesley/modhelper/internal/config"
+	"github.com/ur-wesley/modhelper/internal/r2modman"
 )
 
-func IsInstalled(game internal.Game, targetDir string) bool {
-	gameProfileDir := config.GetGameProfileDir(game)
-	profileName := getProfileName(game)
-	profilePath := filepath.Join(gameProfileDir, profileName)
+func (s *ProfileStore) IsInstalled(game internal.Game) bool {
+	profilePath := s.ProfilePath(game)
 
-	if _, err := os.Stat(profilePath); os.IsNotExist(err) {
+	if _, err := s.fsys.Stat(profilePath); os.IsNotExist(err) {
 		return false
 	}
 
 	modsYmlPath := filepath.Join(profilePath, "mods.yml")
 	bepInExPath := filepath.Join(profilePath, "BepInEx")
 
-	if _, err := os.Stat(modsYmlPath); err == nil {
+	if _, err := s.fsys.Stat(modsYmlPath); err == nil {
 		return true
 	}
-	if _, err := os.Stat(bepInExPath); err == nil {
+	if _, err := s.fsys.Stat(bepInExPath); err == nil {
 		return true
 	}
 
 	return false
 }
 
-func getProfileName(game internal.Game) string {
-	if game.ProfileName != "" {
-		return game.ProfileName
+func (s *ProfileStore) ListProfiles(game internal.Game) ([]string, error) {
+	entries, err := s.fsys.ReadDir(s.GameDir(game))
+	if err != nil {
+		return nil, err
 	}
-	return "Default"
-}
 
-func GetActualProfileName(game internal.Game) string {
-	gameProfileDir := config.GetGameProfileDir(game)
-	manifestProfileName := getProfileName(game)
-
-	fullPath := filepath.Join(gameProfileDir, manifestProfileName)
-	if _, err := os.Stat(fullPath); err == nil {
-		return manifestProfileName
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			names = append(names, entry.Name())
+		}
 	}
+	return names, nil
+}
 
-	variations := []string{
-		manifestProfileName,
-		strings.TrimRight(manifestProfileName, "."),
-		strings.ReplaceAll(manifestProfileName, ".", ""),
-		game.Name,
-		"Default",
+func (s *ProfileStore) InstalledGames() ([]internal.Game, error) {
+	gameDirs, err := s.fsys.ReadDir(s.root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", s.root, err)
 	}
 
-	for _, variation := range variations {
-		testPath := filepath.Join(gameProfileDir, variation)
-		if _, err := os.Stat(testPath); err == nil {
-			log.Printf("Found actual profile directory: '%s' (manifest had: '%s')", variation, manifestProfileName)
-			return variation
+	var games []internal.Game
+	for _, gameDir := range gameDirs {
+		if !gameDir.IsDir() {
+			continue
+		}
+
+		profiles, err := s.fsys.ReadDir(filepath.Join(s.root, gameDir.Name(), "profiles"))
+		if err != nil {
+			continue
+		}
+		for _, profile := range profiles {
+			if !profile.IsDir() {
+				continue
+			}
+
+			game := internal.Game{
+				Name:        gameDir.Name(),
+				GameFolder:  gameDir.Name(),
+				ProfileName: profile.Name(),
+				Variant:     profile.Name(),
+			}
+			if s.manager.Kind == r2modman.KindGale {
+				game.Community = gameDir.Name()
+			}
+			if _, err := s.fsys.Stat(filepath.Join(s.ProfilePath(game), ".profile_version")); err == nil {
+				games = append(games, game)
+			}
 		}
 	}
+	return games, nil
+}
 
-	return manifestProfileName
+func GetProfileName(game internal.Game) string {
+	if game.ProfileName != "" {
+		return game.ProfileName
+	}
+	return "Default"
 }
 
-func GetProfileStatus(game internal.Game, targetDir string) ProfileStatus {
+func (s *ProfileStore) GetProfileStatus(game internal.Game) ProfileStatus {
 	status := ProfileStatus{}
 
-	status.Installed = IsInstalled(game, targetDir)
+	status.Installed = s.IsInstalled(game)
 
 	if !status.Installed {
 		return status
 	}
 
-	upToDate, err := IsProfileUpToDate(game, targetDir)
+	status.Incomplete = s.isIncomplete(game)
+
+	change, installedVersion, err := s.CompareProfileVersion(game)
 	if err != nil {
 		status.VersionError = err
 		status.UpToDate = true
 	} else {
-		status.UpToDate = upToDate
-		status.HasUpdate = !upToDate
-
-		installedVersion, _ := GetInstalledProfileVersion(game, targetDir)
-		log.Printf("Version check for %s: installed='%s', manifest='%s', upToDate=%v, hasUpdate=%v",
-			game.Name, installedVersion, game.Version, upToDate, status.HasUpdate)
+		status.InstalledVersion = installedVersion
+		status.UpToDate = change == VersionEqual
+		status.HasUpdate = change == VersionUpdate
+		status.HasDowngrade = change == VersionDowngrade
+		status.ReinstallRequired = isBelowMinVersion(installedVersion, game.MinVersion)
+
+		logger().Debug("Version check", "game", game.Name, "installed", installedVersion, "manifest", game.Version, "minimum", game.MinVersion,
+			"up_to_date", status.UpToDate, "has_update", status.HasUpdate, "has_downgrade", status.HasDowngrade, "reinstall_required", status.ReinstallRequired)
 	}
 
 	return status
 }
 
-func DeleteProfile(game internal.Game) error {
-	profileDir := config.GetGameProfileDir(game)
-	fullProfilePath := filepath.Join(profileDir, game.ProfileName)
+func (s *ProfileStore) isIncomplete(game internal.Game) bool {
+	profilePath := s.ProfilePath(game)
+
+	s.statusMu.Lock()
+	incomplete, cached := s.incomplete[profilePath]
+	s.statusMu.Unlock()
+	if cached {
+		return incomplete
+	}
+
+	result, err := s.verify(game, false)
+	if err != nil {
+		return false
+	}
+	if result.HasMissingFiles() {
+		logger().Warn("Profile is incomplete", "game", game.Name, "problems", len(result.Problems))
+	}
+	return result.HasMissingFiles()
+}
+
+func (s *ProfileStore) cacheVerifyResult(profilePath string, result *VerifyResult) {
+	s.statusMu.Lock()
+	defer s.statusMu.Unlock()
+
+	if s.incomplete == nil {
+		s.incomplete = make(map[string]bool)
+	}
+	s.incomplete[profilePath] = result.HasMissingFiles()
+}
+
+func (s *ProfileStore) invalidateStatus(profilePath string) {
+	s.statusMu.Lock()
+	defer s.statusMu.Unlock()
+
+	delete(s.incomplete, profilePath)
+}
+
+func (s *ProfileStore) DeleteProfile(game internal.Game) error {
+	fullProfilePath := s.ProfilePath(game)
+	defer s.invalidateStatus(fullProfilePath)
 
-	if _, err := os.Stat(fullProfilePath); os.IsNotExist(err) {
+	if _, err := s.fsys.Stat(fullProfilePath); os.IsNotExist(err) {
 		return nil
 	}
 
-	log.Printf("Deleting profile directory: %s", fullProfilePath)
-	err := os.RemoveAll(fullProfilePath)
+	logger().Info("Deleting profile directory", "path", fullProfilePath)
+	err := s.fsys.RemoveAll(fullProfilePath)
 	if err != nil {
 		return fmt.Errorf("failed to delete profile directory: %w", err)
 	}
 
-	log.Printf("Successfully deleted profile for %s", game.Name)
+	logger().Info("Deleted profile", "game", game.Name)
 	return nil
 }
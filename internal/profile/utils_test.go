@@ -0,0 +1,172 @@
+package profile
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/ur-wesley/modhelper/internal"
+	"github.com/ur-wesley/modhelper/internal/r2modman"
+)
+
+const testRoot = "/data"
+
+func testGame(version string) internal.Game {
+	return internal.Game{
+		Name:        "Test Game",
+		GameFolder:  "TestGame",
+		ProfileName: "Test",
+		URL:         "https://example.com/profile.r2z",
+		Version:     version,
+	}
+}
+
+func newTestStore(t *testing.T) *ProfileStore {
+	t.Helper()
+	return NewProfileStoreWithFS(testRoot, r2modman.Manager{Kind: r2modman.KindR2Modman}, NewMemFS())
+}
+
+func writeTestFile(t *testing.T, store *ProfileStore, name string, data string) {
+	t.Helper()
+	if err := store.fsys.MkdirAll(filepath.Dir(name), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.fsys.WriteFile(name, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func installTestProfile(t *testing.T, store *ProfileStore, game internal.Game, essentials bool) {
+	t.Helper()
+	profilePath := store.ProfilePath(game)
+
+	writeTestFile(t, store, filepath.Join(profilePath, "mods.yml"), "[]\n")
+	if err := store.SaveProfileVersion(game); err != nil {
+		t.Fatal(err)
+	}
+	if !essentials {
+		return
+	}
+
+	writeTestFile(t, store, filepath.Join(profilePath, "BepInEx", "core", "BepInEx.Preloader.dll"), "dll")
+	writeTestFile(t, store, filepath.Join(profilePath, "BepInEx", "config", "BepInEx.cfg"), "[Logging]\n")
+	writeTestFile(t, store, filepath.Join(profilePath, "doorstop_config.ini"), "[General]\n")
+	writeTestFile(t, store, filepath.Join(profilePath, "_state", "installation_state.yml"), "currentState: []\n")
+}
+
+func TestGetProfileStatus(t *testing.T) {
+	tests := []struct {
+		name       string
+		installed  string
+		essentials bool
+		manifest   string
+		want       ProfileStatus
+	}{
+		{
+			name:     "not installed",
+			manifest: "1.0.0",
+			want:     ProfileStatus{},
+		},
+		{
+			name:       "up to date",
+			installed:  "1.0.0",
+			essentials: true,
+			manifest:   "1.0.0",
+			want:       ProfileStatus{Installed: true, UpToDate: true, InstalledVersion: "1.0.0"},
+		},
+		{
+			name:       "update available",
+			installed:  "1.0.0",
+			essentials: true,
+			manifest:   "1.1.0",
+			want:       ProfileStatus{Installed: true, HasUpdate: true, InstalledVersion: "1.0.0"},
+		},
+		{
+			name:      "incomplete",
+			installed: "1.0.0",
+			manifest:  "1.0.0",
+			want:      ProfileStatus{Installed: true, UpToDate: true, Incomplete: true, InstalledVersion: "1.0.0"},
+		},
+		{
+			name:       "downgrade",
+			installed:  "2.0.0",
+			essentials: true,
+			manifest:   "1.5.0",
+			want:       ProfileStatus{Installed: true, HasDowngrade: true, InstalledVersion: "2.0.0"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newTestStore(t)
+			if tt.installed != "" {
+				installTestProfile(t, store, testGame(tt.installed), tt.essentials)
+			}
+
+			got := store.GetProfileStatus(testGame(tt.manifest))
+			if got != tt.want {
+				t.Errorf("GetProfileStatus() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetProfileStatusCachesVerifyResult(t *testing.T) {
+	store := newTestStore(t)
+	game := testGame("1.0.0")
+	installTestProfile(t, store, game, true)
+
+	if store.GetProfileStatus(game).Incomplete {
+		t.Fatal("complete profile reported as incomplete")
+	}
+
+	if err := store.fsys.Remove(filepath.Join(store.ProfilePath(game), "doorstop_config.ini")); err != nil {
+		t.Fatal(err)
+	}
+	if store.GetProfileStatus(game).Incomplete {
+		t.Error("status was recomputed instead of using the cached verify result")
+	}
+
+	if _, err := store.Verify(game); err != nil {
+		t.Fatal(err)
+	}
+	if !store.GetProfileStatus(game).Incomplete {
+		t.Error("status did not pick up the result of an explicit verify")
+	}
+}
+
+func TestReadingStatusCreatesNoDirectories(t *testing.T) {
+	store := newTestStore(t)
+	game := testGame("1.0.0")
+
+	store.GetProfileStatus(game)
+	store.GetInstalledProfileVersion(game)
+	store.LoadInstallReport(game)
+
+	if _, err := store.fsys.Stat(store.GameDir(game)); err == nil {
+		t.Errorf("%s was created by a read-only call", store.GameDir(game))
+	}
+}
+
+func TestListProfiles(t *testing.T) {
+	store := newTestStore(t)
+	game := testGame("1.0.0")
+
+	if _, err := store.ListProfiles(game); err == nil {
+		t.Error("ListProfiles() succeeded without a profiles folder")
+	}
+
+	installTestProfile(t, store, game, false)
+	other := game
+	other.ProfileName = "Other"
+	installTestProfile(t, store, other, false)
+	writeTestFile(t, store, filepath.Join(store.GameDir(game), "notes.txt"), "not a profile")
+
+	names, err := store.ListProfiles(game)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"Other", "Test"}; !slices.Equal(names, want) {
+		t.Errorf("ListProfiles() = %v, want %v", names, want)
+	}
+}
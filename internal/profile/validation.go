@@ -0,0 +1,286 @@
+package profile
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/ur-wesley/modhelper/internal"
+	"github.com/ur-wesley/modhelper/internal/semver"
+)
+
+var (
+	ErrMissingField       = errors.New("required field is missing")
+	ErrInvalidURL         = errors.New("invalid URL")
+	ErrInvalidSteamID     = errors.New("invalid Steam app ID")
+	ErrInvalidCommunity   = errors.New("invalid Thunderstore community")
+	ErrDuplicateEntry     = errors.New("duplicate entry")
+	ErrUnknownPlaceholder = errors.New("unknown placeholder")
+	ErrInvalidVersion     = errors.New("invalid version")
+	ErrInvalidChecksum    = errors.New("invalid sha256 checksum")
+	ErrInvalidFolder      = errors.New("invalid folder name")
+	ErrUnsupportedSchema  = errors.New("unsupported manifest schema")
+	ErrInvalidLaunch      = errors.New("invalid launch options")
+)
+
+var (
+	steamIDPattern     = regexp.MustCompile(`^[0-9]{1,10}$`)
+	communityPattern   = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+	sha256Pattern      = regexp.MustCompile(`^[0-9a-fA-F]{64}$`)
+	placeholderPattern = regexp.MustCompile(`\$\{([^}]*)\}`)
+
+	launchArgPlaceholders = []string{"profileLoc", "profileName"}
+	commandPlaceholders   = []string{"profileLoc", "profileName", "appId", "gameDir", "exe", "args"}
+)
+
+type SchemaError struct {
+	Version int
+}
+
+func (e *SchemaError) Error() string {
+	if e.Version == 0 {
+		return "manifest is missing schemaVersion"
+	}
+	return fmt.Sprintf("manifest schemaVersion %d is newer than supported version %d", e.Version, internal.ManifestSchemaVersion)
+}
+
+func (e *SchemaError) Unwrap() error {
+	return ErrUnsupportedSchema
+}
+
+type ValidationError struct {
+	Index int
+	Game  string
+	Field string
+	Value string
+	Err   error
+}
+
+func (e *ValidationError) Error() string {
+	name := e.Game
+	if name == "" {
+		name = "unnamed"
+	}
+	if e.Value != "" {
+		return fmt.Sprintf("game #%d (%s): %s %q: %v", e.Index+1, name, e.Field, e.Value, e.Err)
+	}
+	return fmt.Sprintf("game #%d (%s): %s: %v", e.Index+1, name, e.Field, e.Err)
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+type ValidationErrors []*ValidationError
+
+func (v ValidationErrors) Error() string {
+	messages := make([]string, 0, len(v))
+	for _, err := range v {
+		messages = append(messages, err.Error())
+	}
+	return strings.Join(messages, "\n")
+}
+
+func (v ValidationErrors) ForGame(index int) ValidationErrors {
+	var result ValidationErrors
+	for _, err := range v {
+		if err.Index == index {
+			result = append(result, err)
+		}
+	}
+	return result
+}
+
+func ValidateManifest(manifest *internal.Manifest) ValidationErrors {
+	var errs ValidationErrors
+
+	seenNames := make(map[string]int)
+	seenProfiles := make(map[string]int)
+
+	for i, game := range manifest.Games {
+		errs = append(errs, ValidateGame(i, game)...)
+
+		if game.Name != "" {
+			key := strings.ToLower(strings.TrimSpace(game.Name))
+			if first, exists := seenNames[key]; exists {
+				errs = append(errs, &ValidationError{
+					Index: i,
+					Game:  game.Name,
+					Field: "name",
+					Value: game.Name,
+					Err:   fmt.Errorf("%w: same name as game #%d", ErrDuplicateEntry, first+1),
+				})
+			} else {
+				seenNames[key] = i
+			}
+		}
+
+		if game.ID != "" {
+			for _, variant := range game.ProfileVariants() {
+				profileName := GetProfileName(game.WithVariant(variant))
+				key := game.ID + "/" + strings.ToLower(profileName)
+				if first, exists := seenProfiles[key]; exists {
+					errs = append(errs, &ValidationError{
+						Index: i,
+						Game:  game.Name,
+						Field: "profileName",
+						Value: profileName,
+						Err:   fmt.Errorf("%w: same Steam ID and profile as game #%d", ErrDuplicateEntry, first+1),
+					})
+				} else {
+					seenProfiles[key] = i
+				}
+			}
+		}
+	}
+
+	return errs
+}
+
+func ValidateGame(index int, game internal.Game) ValidationErrors {
+	var errs ValidationErrors
+
+	add := func(field, value string, err error) {
+		errs = append(errs, &ValidationError{
+			Index: index,
+			Game:  game.Name,
+			Field: field,
+			Value: value,
+			Err:   err,
+		})
+	}
+
+	if strings.TrimSpace(game.Name) == "" {
+		add("name", "", ErrMissingField)
+	}
+
+	if game.ID == "" {
+		add("id", "", ErrMissingField)
+	} else if !steamIDPattern.MatchString(game.ID) {
+		add("id", game.ID, ErrInvalidSteamID)
+	}
+
+	if game.Header != "" && !isHTTPURL(game.Header) {
+		add("icon", game.Header, ErrInvalidURL)
+	}
+
+	if game.GameFolder != "" && (strings.ContainsAny(game.GameFolder, `/\:*?"<>|`) || strings.Trim(game.GameFolder, ".") == "") {
+		add("gameFolder", game.GameFolder, ErrInvalidFolder)
+	}
+
+	needsCommunity := false
+	seenVariants := make(map[string]int)
+
+	for i, variant := range game.ProfileVariants() {
+		prefix := ""
+		if len(game.Variants) > 0 {
+			prefix = fmt.Sprintf("variants[%d].", i)
+
+			key := strings.ToLower(strings.TrimSpace(variant.Name))
+			if key == "" {
+				add(prefix+"name", "", ErrMissingField)
+			} else if first, exists := seenVariants[key]; exists {
+				add(prefix+"name", variant.Name, fmt.Errorf("%w: same name as variant #%d", ErrDuplicateEntry, first+1))
+			} else {
+				seenVariants[key] = i
+			}
+		}
+
+		if variant.URL != "" {
+			needsCommunity = true
+			if !isHTTPURL(variant.URL) {
+				add(prefix+"url", variant.URL, ErrInvalidURL)
+			}
+			if variant.Version == "" {
+				add(prefix+"version", "", ErrMissingField)
+			}
+		}
+
+		if variant.SHA256 != "" && !sha256Pattern.MatchString(variant.SHA256) {
+			add(prefix+"sha256", variant.SHA256, ErrInvalidChecksum)
+		}
+
+		if variant.MinVersion != "" {
+			minVersion, err := semver.Parse(variant.MinVersion)
+			if err != nil {
+				add(prefix+"minVersion", variant.MinVersion, fmt.Errorf("%w: %v", ErrInvalidVersion, err))
+			} else if version, err := semver.Parse(variant.Version); err != nil {
+				add(prefix+"version", variant.Version, fmt.Errorf("%w: minVersion requires a semantic version", ErrInvalidVersion))
+			} else if minVersion.Compare(version) > 0 {
+				add(prefix+"minVersion", variant.MinVersion, fmt.Errorf("%w: greater than version %s", ErrInvalidVersion, variant.Version))
+			}
+		}
+
+		for _, err := range validatePlaceholders(variant.LaunchArgs, launchArgPlaceholders) {
+			add(prefix+"launchArgs", variant.LaunchArgs, err)
+		}
+	}
+
+	if len(game.Variants) > 0 {
+		for _, err := range validatePlaceholders(game.LaunchArgs, launchArgPlaceholders) {
+			add("launchArgs", game.LaunchArgs, err)
+		}
+	}
+
+	launch := game.Launch
+	if launch.Strategy != "" && !slices.Contains(internal.LaunchStrategies, launch.Strategy) {
+		add("launch.strategy", launch.Strategy, fmt.Errorf("%w: strategy must be one of %s", ErrInvalidLaunch, strings.Join(internal.LaunchStrategies, ", ")))
+	}
+	if launch.Strategy == internal.LaunchCommand && strings.TrimSpace(launch.Command) == "" {
+		add("launch.command", "", ErrMissingField)
+	}
+	for _, err := range validatePlaceholders(launch.Command, commandPlaceholders) {
+		add("launch.command", launch.Command, err)
+	}
+	for _, err := range validatePlaceholders(launch.Executable, commandPlaceholders) {
+		add("launch.executable", launch.Executable, err)
+	}
+	for _, key := range slices.Sorted(maps.Keys(launch.Env)) {
+		value := launch.Env[key]
+		if !internal.ValidEnvName(key) {
+			add("launch.env", key, fmt.Errorf("%w: invalid environment variable name", ErrInvalidLaunch))
+		}
+		for _, err := range validatePlaceholders(value, commandPlaceholders) {
+			add("launch.env."+key, value, err)
+		}
+	}
+
+	if needsCommunity && game.Community == "" {
+		add("community", "", ErrMissingField)
+	}
+
+	if game.Community != "" && !communityPattern.MatchString(game.Community) {
+		add("community", game.Community, ErrInvalidCommunity)
+	}
+
+	return errs
+}
+
+func validatePlaceholders(launchArgs string, allowed []string) []error {
+	var errs []error
+
+	matches := placeholderPattern.FindAllStringSubmatch(launchArgs, -1)
+	for _, match := range matches {
+		if !slices.Contains(allowed, match[1]) {
+			errs = append(errs, fmt.Errorf("%w: ${%s}", ErrUnknownPlaceholder, match[1]))
+		}
+	}
+
+	if strings.Count(launchArgs, "${") != len(matches) {
+		errs = append(errs, fmt.Errorf("%w: unterminated ${", ErrUnknownPlaceholder))
+	}
+
+	return errs
+}
+
+func isHTTPURL(raw string) bool {
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return false
+	}
+	return (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+}
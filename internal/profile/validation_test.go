@@ -0,0 +1,212 @@
+package profile
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ur-wesley/modhelper/internal"
+)
+
+func validManifestGame() internal.Game {
+	return internal.Game{
+		Name:        "R.E.P.O.",
+		ID:          "3241660",
+		Header:      "https://example.com/repo.png",
+		ProfileName: "Modded",
+		URL:         "https://example.com/repo.r2z",
+		LaunchArgs:  `--doorstop-enable true --doorstop-target "${profileLoc}\${profileName}\BepInEx\core\BepInEx.Preloader.dll"`,
+		Community:   "repo",
+		GameFolder:  "REPO",
+		Version:     "1.2.0",
+		SHA256:      strings.Repeat("ab", 32),
+		MinVersion:  "1.0.0",
+	}
+}
+
+func TestValidateGame(t *testing.T) {
+	tests := []struct {
+		name      string
+		mutate    func(game *internal.Game)
+		wantField string
+		wantErr   error
+	}{
+		{name: "valid game", mutate: func(game *internal.Game) {}},
+		{
+			name: "valid variants and launch options",
+			mutate: func(game *internal.Game) {
+				game.Variants = []internal.ProfileVariant{
+					{Name: "Vanilla+", URL: "https://example.com/a.r2z", Version: "1.0.0"},
+					{Name: "Hardcore", URL: "https://example.com/b.r2z", Version: "2.0.0-beta.1", LaunchArgs: "${profileName}"},
+				}
+				game.Launch = internal.LaunchOptions{
+					Strategy:   internal.LaunchCommand,
+					Executable: `${gameDir}\REPO.exe`,
+					Command:    `"${exe}" ${args}`,
+					Env:        map[string]string{"WINEPREFIX": `${gameDir}\pfx`},
+				}
+			},
+		},
+		{name: "no profile URL needs no community", mutate: func(game *internal.Game) { game.URL, game.Community = "", "" }},
+
+		{name: "missing name", mutate: func(game *internal.Game) { game.Name = " " }, wantField: "name", wantErr: ErrMissingField},
+		{name: "missing id", mutate: func(game *internal.Game) { game.ID = "" }, wantField: "id", wantErr: ErrMissingField},
+		{name: "non-numeric id", mutate: func(game *internal.Game) { game.ID = "repo" }, wantField: "id", wantErr: ErrInvalidSteamID},
+		{name: "icon is not http", mutate: func(game *internal.Game) { game.Header = "file:///C:/repo.png" }, wantField: "icon", wantErr: ErrInvalidURL},
+		{name: "game folder with separator", mutate: func(game *internal.Game) { game.GameFolder = `..\REPO` }, wantField: "gameFolder", wantErr: ErrInvalidFolder},
+		{name: "game folder of dots", mutate: func(game *internal.Game) { game.GameFolder = ".." }, wantField: "gameFolder", wantErr: ErrInvalidFolder},
+		{name: "profile URL is not http", mutate: func(game *internal.Game) { game.URL = "ftp://example.com/repo.r2z" }, wantField: "url", wantErr: ErrInvalidURL},
+		{name: "profile URL without version", mutate: func(game *internal.Game) { game.Version, game.MinVersion = "", "" }, wantField: "version", wantErr: ErrMissingField},
+		{name: "bad checksum", mutate: func(game *internal.Game) { game.SHA256 = "abc" }, wantField: "sha256", wantErr: ErrInvalidChecksum},
+		{name: "bad min version", mutate: func(game *internal.Game) { game.MinVersion = "one" }, wantField: "minVersion", wantErr: ErrInvalidVersion},
+		{name: "min version needs semantic version", mutate: func(game *internal.Game) { game.Version = "latest" }, wantField: "version", wantErr: ErrInvalidVersion},
+		{name: "min version above version", mutate: func(game *internal.Game) { game.MinVersion = "2.0.0" }, wantField: "minVersion", wantErr: ErrInvalidVersion},
+		{name: "unknown launch placeholder", mutate: func(game *internal.Game) { game.LaunchArgs = "${gameDir}" }, wantField: "launchArgs", wantErr: ErrUnknownPlaceholder},
+		{name: "unterminated launch placeholder", mutate: func(game *internal.Game) { game.LaunchArgs = "${profileLoc" }, wantField: "launchArgs", wantErr: ErrUnknownPlaceholder},
+		{name: "missing community", mutate: func(game *internal.Game) { game.Community = "" }, wantField: "community", wantErr: ErrMissingField},
+		{name: "invalid community", mutate: func(game *internal.Game) { game.Community = "R.E.P.O" }, wantField: "community", wantErr: ErrInvalidCommunity},
+
+		{
+			name: "variant without name",
+			mutate: func(game *internal.Game) {
+				game.Variants = []internal.ProfileVariant{{URL: "https://example.com/a.r2z", Version: "1.0.0"}}
+			},
+			wantField: "variants[0].name",
+			wantErr:   ErrMissingField,
+		},
+		{
+			name: "duplicate variant name",
+			mutate: func(game *internal.Game) {
+				game.Variants = []internal.ProfileVariant{{Name: "Hardcore"}, {Name: " hardcore "}}
+			},
+			wantField: "variants[1].name",
+			wantErr:   ErrDuplicateEntry,
+		},
+		{
+			name: "variant with bad URL",
+			mutate: func(game *internal.Game) {
+				game.Variants = []internal.ProfileVariant{{Name: "Hardcore", URL: "example.com/b.r2z", Version: "1.0.0"}}
+			},
+			wantField: "variants[0].url",
+			wantErr:   ErrInvalidURL,
+		},
+		{
+			name: "base launch args with variants",
+			mutate: func(game *internal.Game) {
+				game.Variants = []internal.ProfileVariant{{Name: "Hardcore"}}
+				game.LaunchArgs = "${appId}"
+			},
+			wantField: "launchArgs",
+			wantErr:   ErrUnknownPlaceholder,
+		},
+
+		{name: "unknown launch strategy", mutate: func(game *internal.Game) { game.Launch.Strategy = "proton" }, wantField: "launch.strategy", wantErr: ErrInvalidLaunch},
+		{name: "command strategy without command", mutate: func(game *internal.Game) { game.Launch.Strategy = internal.LaunchCommand }, wantField: "launch.command", wantErr: ErrMissingField},
+		{name: "unknown command placeholder", mutate: func(game *internal.Game) { game.Launch.Command = "${home}/run.sh" }, wantField: "launch.command", wantErr: ErrUnknownPlaceholder},
+		{name: "unknown executable placeholder", mutate: func(game *internal.Game) { game.Launch.Executable = "${steam}/REPO.exe" }, wantField: "launch.executable", wantErr: ErrUnknownPlaceholder},
+		{name: "invalid env name", mutate: func(game *internal.Game) { game.Launch.Env = map[string]string{"MY VAR": "1"} }, wantField: "launch.env", wantErr: ErrInvalidLaunch},
+		{name: "unknown env placeholder", mutate: func(game *internal.Game) { game.Launch.Env = map[string]string{"PREFIX": "${home}"} }, wantField: "launch.env.PREFIX", wantErr: ErrUnknownPlaceholder},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := validManifestGame()
+			tt.mutate(&game)
+
+			errs := ValidateGame(2, game)
+			if tt.wantErr == nil {
+				if len(errs) > 0 {
+					t.Errorf("ValidateGame() = %v, want no errors", errs)
+				}
+				return
+			}
+
+			for _, err := range errs {
+				if err.Field == tt.wantField && errors.Is(err, tt.wantErr) {
+					if err.Index != 2 {
+						t.Errorf("error index = %d, want 2", err.Index)
+					}
+					return
+				}
+			}
+			t.Errorf("ValidateGame() = %v, want %s: %v", errs, tt.wantField, tt.wantErr)
+		})
+	}
+}
+
+func TestValidateManifestDuplicates(t *testing.T) {
+	variantGame := validManifestGame()
+	variantGame.Name = "R.E.P.O. Variants"
+	variantGame.Variants = []internal.ProfileVariant{{Name: "Modded", URL: "https://example.com/a.r2z", Version: "1.0.0"}}
+
+	otherProfile := validManifestGame()
+	otherProfile.Name = "R.E.P.O. Vanilla"
+	otherProfile.ProfileName = "Vanilla"
+
+	tests := []struct {
+		name      string
+		games     []internal.Game
+		wantIndex int
+		wantField string
+	}{
+		{name: "distinct games", games: []internal.Game{validManifestGame(), otherProfile}, wantIndex: -1},
+		{name: "same name", games: []internal.Game{validManifestGame(), func() internal.Game {
+			game := otherProfile
+			game.Name = "r.e.p.o."
+			return game
+		}()}, wantIndex: 1, wantField: "name"},
+		{name: "same Steam ID and profile", games: []internal.Game{validManifestGame(), variantGame}, wantIndex: 1, wantField: "profileName"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := ValidateManifest(&internal.Manifest{SchemaVersion: internal.ManifestSchemaVersion, Games: tt.games})
+			if tt.wantIndex < 0 {
+				if len(errs) > 0 {
+					t.Errorf("ValidateManifest() = %v, want no errors", errs)
+				}
+				return
+			}
+
+			gameErrs := errs.ForGame(tt.wantIndex)
+			if len(gameErrs) != 1 || gameErrs[0].Field != tt.wantField || !errors.Is(gameErrs[0], ErrDuplicateEntry) {
+				t.Errorf("errors for game #%d = %v, want duplicate %s", tt.wantIndex+1, gameErrs, tt.wantField)
+			}
+			if len(errs.ForGame(0)) > 0 {
+				t.Errorf("first game reported as duplicate: %v", errs.ForGame(0))
+			}
+		})
+	}
+}
+
+func TestParseManifestSchema(t *testing.T) {
+	tests := []struct {
+		name        string
+		data        string
+		wantVersion int
+		wantErr     error
+	}{
+		{name: "legacy array", data: `[{"name":"R.E.P.O."}]`, wantVersion: 1},
+		{name: "current schema with BOM", data: "\xef\xbb\xbf" + `{"schemaVersion":2,"games":[]}`, wantVersion: 2},
+		{name: "missing schema", data: `{"games":[]}`, wantErr: ErrUnsupportedSchema},
+		{name: "newer schema", data: `{"schemaVersion":99,"games":[]}`, wantErr: ErrUnsupportedSchema},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manifest, err := ParseManifest([]byte(tt.data))
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("ParseManifest() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if manifest.SchemaVersion != tt.wantVersion {
+				t.Errorf("schemaVersion = %d, want %d", manifest.SchemaVersion, tt.wantVersion)
+			}
+		})
+	}
+}
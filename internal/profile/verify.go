@@ -0,0 +1,366 @@
+package profile
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/ur-wesley/modhelper/internal"
+)
+
+const fileIndexFile = ".profile_files.json"
+
+type FileIndex struct {
+	Files []IndexedFile     `json:"files"`
+	Mods  map[string]string `json:"mods"`
+}
+
+type IndexedFile struct {
+	Path   string `json:"path"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+	Mod    string `json:"mod,omitempty"`
+	Config bool   `json:"config,omitempty"`
+}
+
+type ProblemKind string
+
+const (
+	ProblemMissing          ProblemKind = "missing"
+	ProblemCorrupt          ProblemKind = "corrupt"
+	ProblemConfigMissing    ProblemKind = "config-missing"
+	ProblemEssentialMissing ProblemKind = "essential-missing"
+	ProblemModMissing       ProblemKind = "mod-missing"
+)
+
+type VerifyProblem struct {
+	Kind ProblemKind
+	Path string
+	Mod  string
+}
+
+type VerifyResult struct {
+	Indexed  bool
+	Checked  int
+	Problems []VerifyProblem
+}
+
+func (r *VerifyResult) OK() bool {
+	return len(r.Problems) == 0
+}
+
+func (r *VerifyResult) HasMissingFiles() bool {
+	for _, problem := range r.Problems {
+		if problem.Kind != ProblemModMissing {
+			return true
+		}
+	}
+	return false
+}
+
+const stateEssential = "installation_state.yml"
+
+func loaderEssentials(profilePath string) map[string][]string {
+	return map[string][]string{
+		"BepInEx.Preloader.dll": {
+			filepath.Join(profilePath, "BepInEx", "core", "BepInEx.Preloader.dll"),
+			filepath.Join(profilePath, "config", "BepInEx.Preloader.dll"),
+		},
+		"BepInEx.cfg": {
+			filepath.Join(profilePath, "config", "BepInEx.cfg"),
+			filepath.Join(profilePath, "BepInEx", "config", "BepInEx.cfg"),
+			filepath.Join(profilePath, "BepInEx.cfg"),
+		},
+		"doorstop_config.ini": {
+			filepath.Join(profilePath, "doorstop_config.ini"),
+			filepath.Join(profilePath, "config", "doorstop_config.ini"),
+		},
+		stateEssential: {
+			filepath.Join(profilePath, "_state", "installation_state.yml"),
+		},
+	}
+}
+
+func (s *ProfileStore) missingEssentials(profilePath string, r2z bool) []string {
+	var missing []string
+	for fileName, possiblePaths := range loaderEssentials(profilePath) {
+		if fileName == stateEssential && !r2z {
+			continue
+		}
+
+		found := false
+		for _, checkPath := range possiblePaths {
+			if _, err := s.fsys.Stat(checkPath); err == nil {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, fileName)
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
+
+func (index *FileIndex) addMod(profilePath string, mod *resolvedMod, written []string) {
+	if index.Mods == nil {
+		index.Mods = make(map[string]string)
+	}
+	index.Mods[mod.FullName] = mod.Version.VersionNumber
+
+	for _, file := range written {
+		rel, err := filepath.Rel(profilePath, file)
+		if err != nil {
+			continue
+		}
+		index.setOwner(filepath.ToSlash(rel), mod.FullName)
+	}
+}
+
+func (index *FileIndex) setOwner(rel, mod string) {
+	for i := range index.Files {
+		if index.Files[i].Path == rel {
+			index.Files[i].Mod = mod
+			return
+		}
+	}
+	index.Files = append(index.Files, IndexedFile{Path: rel, Mod: mod})
+}
+
+func (index *FileIndex) put(file IndexedFile) {
+	for i := range index.Files {
+		if index.Files[i].Path == file.Path {
+			index.Files[i] = file
+			return
+		}
+	}
+	index.Files = append(index.Files, file)
+}
+
+func (index *FileIndex) owner(rel string) string {
+	for _, file := range index.Files {
+		if file.Path == rel {
+			return file.Mod
+		}
+	}
+	return ""
+}
+
+func isIndexedPath(rel string) bool {
+	base := path.Base(rel)
+	switch {
+	case strings.HasPrefix(base, "."),
+		rel == "mods.yml",
+		strings.HasPrefix(rel, "_state/"),
+		strings.HasPrefix(rel, "BepInEx/cache/"),
+		rel == "BepInEx/LogOutput.log":
+		return false
+	}
+	return true
+}
+
+func (s *ProfileStore) hashIndexedFile(profilePath string, file *IndexedFile) error {
+	data, err := s.fsys.ReadFile(filepath.Join(profilePath, filepath.FromSlash(file.Path)))
+	if err != nil {
+		return err
+	}
+	file.Size = int64(len(data))
+	file.SHA256 = hashBytes(data)
+	file.Config = isConfigPath(file.Path)
+	return nil
+}
+
+func (s *ProfileStore) listProfileFiles(profilePath string) ([]string, error) {
+	var files []string
+
+	var walk func(dir, rel string) error
+	walk = func(dir, rel string) error {
+		entries, err := s.fsys.ReadDir(dir)
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			entryRel := path.Join(rel, entry.Name())
+			if entry.IsDir() {
+				if err := walk(filepath.Join(dir, entry.Name()), entryRel); err != nil {
+					return err
+				}
+				continue
+			}
+			if isIndexedPath(entryRel) {
+				files = append(files, entryRel)
+			}
+		}
+		return nil
+	}
+
+	if err := walk(profilePath, ""); err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+func (s *ProfileStore) writeFileIndex(game internal.Game, index *FileIndex) error {
+	profilePath := s.ProfilePath(game)
+
+	files, err := s.listProfileFiles(profilePath)
+	if err != nil {
+		return fmt.Errorf("failed to list profile files: %w", err)
+	}
+
+	recorded := FileIndex{Mods: index.Mods}
+	for _, rel := range files {
+		file := IndexedFile{Path: rel, Mod: index.owner(rel)}
+		if err := s.hashIndexedFile(profilePath, &file); err != nil {
+			return fmt.Errorf("failed to hash %s: %w", rel, err)
+		}
+		recorded.Files = append(recorded.Files, file)
+	}
+
+	if err := s.saveFileIndex(profilePath, &recorded); err != nil {
+		return err
+	}
+
+	logger().Info("Recorded installed files", "game", game.Name, "count", len(recorded.Files))
+	return nil
+}
+
+func (s *ProfileStore) saveFileIndex(profilePath string, index *FileIndex) error {
+	data, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal file index: %w", err)
+	}
+	if err := s.fsys.WriteFile(filepath.Join(profilePath, fileIndexFile), data, 0644); err != nil {
+		return fmt.Errorf("failed to write file index: %w", err)
+	}
+	return nil
+}
+
+func (s *ProfileStore) loadFileIndex(profilePath string) (*FileIndex, error) {
+	data, err := s.fsys.ReadFile(filepath.Join(profilePath, fileIndexFile))
+	if err != nil {
+		return nil, err
+	}
+
+	var index FileIndex
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, fmt.Errorf("failed to parse file index: %w", err)
+	}
+	return &index, nil
+}
+
+func (s *ProfileStore) readProfileMods(profilePath string) (map[string]string, error) {
+	data, err := s.fsys.ReadFile(filepath.Join(profilePath, "mods.yml"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read mods.yml: %w", err)
+	}
+
+	var modsYML ModsYML
+	if err := yaml.Unmarshal(data, &modsYML); err != nil {
+		return nil, fmt.Errorf("failed to parse mods.yml: %w", err)
+	}
+
+	mods := make(map[string]string)
+	for _, mod := range modsYML {
+		if mod.Name == "_ProfileVersion" || !mod.Enabled {
+			continue
+		}
+		mods[mod.Name] = fmt.Sprintf("%d.%d.%d", mod.VersionNumber.Major, mod.VersionNumber.Minor, mod.VersionNumber.Patch)
+	}
+	return mods, nil
+}
+
+func (s *ProfileStore) Verify(game internal.Game) (*VerifyResult, error) {
+	return s.verify(game, true)
+}
+
+func (s *ProfileStore) verify(game internal.Game, hashFiles bool) (*VerifyResult, error) {
+	profilePath := s.ProfilePath(game)
+	if _, err := s.fsys.Stat(profilePath); err != nil {
+		return nil, fmt.Errorf("profile %s is not installed: %w", GetProfileName(game), err)
+	}
+
+	result := &VerifyResult{}
+
+	index, err := s.loadFileIndex(profilePath)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	r2z := index != nil && index.Mods != nil
+	for _, name := range s.missingEssentials(profilePath, r2z) {
+		problem := VerifyProblem{Kind: ProblemEssentialMissing, Path: name}
+		if index != nil {
+			for _, candidate := range loaderEssentials(".")[name] {
+				if owner := index.owner(filepath.ToSlash(filepath.Clean(candidate))); owner != "" {
+					problem.Mod = owner
+					break
+				}
+			}
+		}
+		result.Problems = append(result.Problems, problem)
+	}
+
+	if index == nil {
+		logger().Info("No file index, only checking loader essentials", "game", game.Name)
+		s.cacheVerifyResult(profilePath, result)
+		return result, nil
+	}
+	result.Indexed = true
+
+	for _, file := range index.Files {
+		result.Checked++
+
+		filePath := filepath.Join(profilePath, filepath.FromSlash(file.Path))
+		info, err := s.fsys.Stat(filePath)
+		if err != nil {
+			kind := ProblemMissing
+			if file.Config {
+				kind = ProblemConfigMissing
+			}
+			result.Problems = append(result.Problems, VerifyProblem{Kind: kind, Path: file.Path, Mod: file.Mod})
+			continue
+		}
+		if file.Config || !hashFiles {
+			continue
+		}
+
+		if info.Size() != file.Size {
+			result.Problems = append(result.Problems, VerifyProblem{Kind: ProblemCorrupt, Path: file.Path, Mod: file.Mod})
+			continue
+		}
+		data, err := s.fsys.ReadFile(filePath)
+		if err != nil || hashBytes(data) != file.SHA256 {
+			result.Problems = append(result.Problems, VerifyProblem{Kind: ProblemCorrupt, Path: file.Path, Mod: file.Mod})
+		}
+	}
+
+	if index.Mods != nil {
+		mods, err := s.readProfileMods(profilePath)
+		if err != nil {
+			return nil, err
+		}
+		names := make([]string, 0, len(mods))
+		for name := range mods {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if _, ok := index.Mods[name]; !ok {
+				result.Problems = append(result.Problems, VerifyProblem{Kind: ProblemModMissing, Mod: name})
+			}
+		}
+	}
+
+	logger().Info("Verified profile", "game", game.Name, "checked", result.Checked, "problems", len(result.Problems))
+	s.cacheVerifyResult(profilePath, result)
+	return result, nil
+}
@@ -0,0 +1,44 @@
+package profile
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestVerifyRequiresInstallationStateOnlyForR2Z(t *testing.T) {
+	tests := []struct {
+		name   string
+		index  *FileIndex
+		wantOK bool
+	}{
+		{name: "zip install without index", wantOK: true},
+		{name: "zip install", index: &FileIndex{}, wantOK: true},
+		{name: "r2z install", index: &FileIndex{Mods: map[string]string{}}, wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newTestStore(t)
+			game := testGame("1.0.0")
+			installTestProfile(t, store, game, true)
+
+			profilePath := store.ProfilePath(game)
+			if err := store.fsys.RemoveAll(filepath.Join(profilePath, "_state")); err != nil {
+				t.Fatal(err)
+			}
+			if tt.index != nil {
+				if err := store.saveFileIndex(profilePath, tt.index); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			result, err := store.Verify(game)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.OK() != tt.wantOK {
+				t.Errorf("Verify() problems = %+v, want OK %v", result.Problems, tt.wantOK)
+			}
+		})
+	}
+}
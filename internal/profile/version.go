@@ -3,25 +3,21 @@ package profile
 import (
 	"encoding/json"
 	"fmt"
-	"log"
-	"os"
 	"path/filepath"
 	"time"
 
 	"gopkg.in/yaml.v3"
 
 	"github.com/ur-wesley/modhelper/internal"
-	"github.com/ur-wesley/modhelper/internal/config"
+	"github.com/ur-wesley/modhelper/internal/semver"
 )
 
 func GetProfileVersion(game internal.Game) string {
 	return game.Version
 }
 
-func SaveProfileVersion(game internal.Game, targetDir string) error {
-	profileDir := config.GetGameProfileDir(game)
-
-	versionFile := filepath.Join(profileDir, game.ProfileName, ".profile_version")
+func (s *ProfileStore) SaveProfileVersion(game internal.Game) error {
+	versionFile := filepath.Join(s.ProfilePath(game), ".profile_version")
 
 	versionData := ProfileVersion{
 		URL:     game.URL,
@@ -33,33 +29,31 @@ func SaveProfileVersion(game internal.Game, targetDir string) error {
 		return fmt.Errorf("failed to marshal version data: %w", err)
 	}
 
-	err = os.WriteFile(versionFile, data, 0644)
+	err = s.fsys.WriteFile(versionFile, data, 0644)
 	if err != nil {
 		return fmt.Errorf("failed to write version file: %w", err)
 	}
 
-	log.Printf("Saved profile version for %s: %s", game.Name, game.Version)
+	logger().Info("Saved profile version", "game", game.Name, "version", game.Version)
 	return nil
 }
 
-func GetInstalledProfileVersion(game internal.Game, targetDir string) (string, error) {
-	profileDir := config.GetGameProfileDir(game)
-
-	versionFile := filepath.Join(profileDir, game.ProfileName, ".profile_version")
+func (s *ProfileStore) GetInstalledProfileVersion(game internal.Game) (string, error) {
+	versionFile := filepath.Join(s.ProfilePath(game), ".profile_version")
 
-	data, err := os.ReadFile(versionFile)
+	data, err := s.fsys.ReadFile(versionFile)
 	if err == nil {
 		var versionData ProfileVersion
 		err = json.Unmarshal(data, &versionData)
 		if err == nil {
 			return versionData.Version, nil
 		}
-		log.Printf("Warning: Could not parse .profile_version for %s: %v", game.Name, err)
+		logger().Warn("Could not parse .profile_version", "game", game.Name, "error", err)
 	}
 
-	version, err := GetVersionFromModsYML(game, targetDir)
+	version, err := s.GetVersionFromModsYML(game)
 	if err != nil {
-		log.Printf("Warning: Could not get version from mods.yml for %s: %v", game.Name, err)
+		logger().Warn("Could not get version from mods.yml", "game", game.Name, "error", err)
 	}
 	if version != "" {
 		return version, nil
@@ -68,39 +62,80 @@ func GetInstalledProfileVersion(game internal.Game, targetDir string) (string, e
 	return "", nil
 }
 
-func IsProfileUpToDate(game internal.Game, targetDir string) (bool, error) {
+func (s *ProfileStore) IsProfileUpToDate(game internal.Game) (bool, error) {
+	change, _, err := s.CompareProfileVersion(game)
+	if err != nil {
+		return true, err
+	}
+	return change == VersionEqual, nil
+}
+
+func (s *ProfileStore) CompareProfileVersion(game internal.Game) (VersionChange, string, error) {
 	if game.URL == "" || game.Version == "" {
-		return true, nil
+		return VersionEqual, "", nil
 	}
 
-	installedVersion, err := GetInstalledProfileVersion(game, targetDir)
+	installedVersion, err := s.GetInstalledProfileVersion(game)
 	if err != nil {
-		log.Printf("Warning: Could not check profile version for %s: %v", game.Name, err)
-		return true, nil
+		logger().Warn("Could not check profile version", "game", game.Name, "error", err)
+		return VersionEqual, "", nil
+	}
+
+	change := compareProfileVersions(installedVersion, game.Version)
+	switch change {
+	case VersionUpdate:
+		logger().Info("Profile update available", "game", game.Name, "from", installedVersion, "to", game.Version)
+	case VersionDowngrade:
+		logger().Info("Profile was rolled back", "game", game.Name, "from", installedVersion, "to", game.Version)
 	}
 
+	return change, installedVersion, nil
+}
+
+func compareProfileVersions(installedVersion, currentVersion string) VersionChange {
 	if installedVersion == "" {
-		return false, nil
+		return VersionUpdate
 	}
 
-	currentVersion := game.Version
-	isUpToDate := installedVersion == currentVersion
-	if !isUpToDate {
-		log.Printf("Profile update available for %s: %s -> %s", game.Name, installedVersion, currentVersion)
+	c, err := semver.Compare(currentVersion, installedVersion)
+	if err != nil {
+		logger().Warn("Falling back to exact version match", "installed", installedVersion, "current", currentVersion, "error", err)
+		if installedVersion == currentVersion {
+			return VersionEqual
+		}
+		return VersionUpdate
 	}
 
-	return isUpToDate, nil
+	switch {
+	case c > 0:
+		return VersionUpdate
+	case c < 0:
+		return VersionDowngrade
+	default:
+		return VersionEqual
+	}
 }
 
-func SaveProfileVersionInModsYML(game internal.Game, targetDir string) error {
-	profileDir := config.GetGameProfileDir(game)
+func isBelowMinVersion(installedVersion, minVersion string) bool {
+	if installedVersion == "" || minVersion == "" {
+		return false
+	}
 
-	modsYMLPath := filepath.Join(profileDir, game.ProfileName, "mods.yml")
+	c, err := semver.Compare(installedVersion, minVersion)
+	if err != nil {
+		logger().Warn("Could not compare installed version with minimum", "installed", installedVersion, "minimum", minVersion, "error", err)
+		return false
+	}
+	return c < 0
+}
+
+func (s *ProfileStore) SaveProfileVersionInModsYML(game internal.Game) error {
+	modsYMLPath := filepath.Join(s.ProfilePath(game), "mods.yml")
 
 	var modsYML ModsYML
-	if data, err := os.ReadFile(modsYMLPath); err == nil {
+	if data, err := s.fsys.ReadFile(modsYMLPath); err == nil {
 		if err := yaml.Unmarshal(data, &modsYML); err != nil {
-			log.Printf("Warning: Could not parse existing mods.yml for %s: %v", game.Name, err)
+			logger().Warn("Could not parse existing mods.yml", "game", game.Name, "error", err)
 		}
 	}
 
@@ -143,21 +178,19 @@ func SaveProfileVersionInModsYML(game internal.Game, targetDir string) error {
 		return fmt.Errorf("failed to marshal mods.yml with version: %w", err)
 	}
 
-	err = os.WriteFile(modsYMLPath, data, 0644)
+	err = s.fsys.WriteFile(modsYMLPath, data, 0644)
 	if err != nil {
 		return fmt.Errorf("failed to write mods.yml with version: %w", err)
 	}
 
-	log.Printf("Saved profile version %s in mods.yml for %s", game.Version, game.Name)
+	logger().Info("Saved profile version in mods.yml", "game", game.Name, "version", game.Version)
 	return nil
 }
 
-func GetVersionFromModsYML(game internal.Game, targetDir string) (string, error) {
-	profileDir := config.GetGameProfileDir(game)
-
-	modsYMLPath := filepath.Join(profileDir, game.ProfileName, "mods.yml")
+func (s *ProfileStore) GetVersionFromModsYML(game internal.Game) (string, error) {
+	modsYMLPath := filepath.Join(s.ProfilePath(game), "mods.yml")
 
-	data, err := os.ReadFile(modsYMLPath)
+	data, err := s.fsys.ReadFile(modsYMLPath)
 	if err != nil {
 		return "", nil
 	}
@@ -0,0 +1,131 @@
+package r2modman
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ur-wesley/modhelper/internal"
+)
+
+type GameIdentifier struct {
+	SteamID      string
+	Community    string
+	Folder       string
+	LegacyFolder string
+}
+
+var knownGames = []GameIdentifier{
+	{SteamID: "632360", Community: "riskofrain2", Folder: "RiskOfRain2"},
+	{SteamID: "892970", Community: "valheim", Folder: "Valheim"},
+	{SteamID: "1092790", Community: "inscryption", Folder: "Inscryption"},
+	{SteamID: "493520", Community: "gtfo", Folder: "GTFO"},
+	{SteamID: "945360", Community: "among-us", Folder: "AmongUs"},
+	{SteamID: "1366540", Community: "dyson-sphere-program", Folder: "DysonSphereProgram"},
+	{SteamID: "794260", Community: "outward", Folder: "Outward"},
+	{SteamID: "1592190", Community: "bonelab", Folder: "BONELAB"},
+	{SteamID: "1625450", Community: "muck", Folder: "Muck"},
+	{SteamID: "1432860", Community: "sun-haven", Folder: "SunHaven"},
+	{SteamID: "1229490", Community: "ultrakill", Folder: "ULTRAKILL"},
+	{SteamID: "1966720", Community: "lethal-company", Folder: "LethalCompany"},
+	{SteamID: "2881650", Community: "content-warning", Folder: "ContentWarning"},
+	{SteamID: "3241660", Community: "repo", Folder: "REPO", LegacyFolder: "R.E.P.O"},
+}
+
+func LookupGame(steamID, community string) (GameIdentifier, bool) {
+	for _, identifier := range knownGames {
+		if steamID != "" && identifier.SteamID == steamID {
+			return identifier, true
+		}
+	}
+	for _, identifier := range knownGames {
+		if community != "" && strings.EqualFold(identifier.Community, community) {
+			return identifier, true
+		}
+	}
+	return GameIdentifier{}, false
+}
+
+func GameFolder(game internal.Game) string {
+	if game.GameFolder != "" {
+		return game.GameFolder
+	}
+
+	if identifier, ok := LookupGame(game.ID, game.Community); ok {
+		return identifier.Folder
+	}
+
+	folder := strings.TrimRight(strings.ReplaceAll(game.Name, " ", ""), ".")
+	logger().Warn("No r2modman identifier known, set gameFolder in the manifest to override", "game", game.Name, "steam_id", game.ID, "folder", folder)
+	return folder
+}
+
+func MoveLegacyFolders(dataDir string) {
+	if dataDir == "" {
+		return
+	}
+
+	for _, identifier := range knownGames {
+		if identifier.LegacyFolder == "" {
+			continue
+		}
+
+		legacy := filepath.Join(dataDir, identifier.LegacyFolder)
+		current := filepath.Join(dataDir, identifier.Folder)
+		if !isDir(legacy) {
+			continue
+		}
+		if existingPath(current) != "" {
+			moveLegacyProfiles(legacy, current)
+			continue
+		}
+
+		if err := os.Rename(legacy, current); err != nil {
+			logger().Warn("Could not move legacy game folder", "from", legacy, "to", current, "error", err)
+			continue
+		}
+		logger().Info("Moved legacy game folder", "from", legacy, "to", current)
+	}
+}
+
+func moveLegacyProfiles(legacy, current string) {
+	legacyProfiles := filepath.Join(legacy, "profiles")
+	currentProfiles := filepath.Join(current, "profiles")
+
+	entries, err := os.ReadDir(legacyProfiles)
+	if err != nil && !os.IsNotExist(err) {
+		logger().Warn("Could not read legacy profiles", "dir", legacyProfiles, "error", err)
+		return
+	}
+	if err := os.MkdirAll(currentProfiles, 0755); err != nil {
+		logger().Warn("Could not create profiles folder", "dir", currentProfiles, "error", err)
+		return
+	}
+
+	for _, entry := range entries {
+		from := filepath.Join(legacyProfiles, entry.Name())
+		to := filepath.Join(currentProfiles, entry.Name())
+		if existingPath(to) != "" {
+			logger().Warn("Legacy profile left in place, a profile with the same name already exists", "legacy", from, "current", to)
+			continue
+		}
+		if err := os.Rename(from, to); err != nil {
+			logger().Warn("Could not move legacy profile", "from", from, "to", to, "error", err)
+			continue
+		}
+		logger().Info("Moved legacy profile", "from", from, "to", to)
+	}
+
+	os.Remove(legacyProfiles)
+	os.Remove(legacy)
+}
+
+func galeSlug(game internal.Game) string {
+	if game.Community != "" {
+		return game.Community
+	}
+	if identifier, ok := LookupGame(game.ID, ""); ok {
+		return identifier.Community
+	}
+	return strings.ToLower(GameFolder(game))
+}
@@ -0,0 +1,77 @@
+package r2modman
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMoveLegacyFolders(t *testing.T) {
+	tests := []struct {
+		name         string
+		legacy       map[string]string
+		current      map[string]string
+		wantLegacy   bool
+		wantProfiles map[string]string
+	}{
+		{
+			name:         "legacy folder is moved",
+			legacy:       map[string]string{"Default": "legacy"},
+			wantProfiles: map[string]string{"Default": "legacy"},
+		},
+		{
+			name:         "legacy profiles are moved into the existing folder",
+			legacy:       map[string]string{"Modded": "legacy"},
+			current:      map[string]string{"Default": "current"},
+			wantProfiles: map[string]string{"Default": "current", "Modded": "legacy"},
+		},
+		{
+			name:         "clashing profile is kept in the legacy folder",
+			legacy:       map[string]string{"Default": "legacy", "Modded": "legacy"},
+			current:      map[string]string{"Default": "current"},
+			wantLegacy:   true,
+			wantProfiles: map[string]string{"Default": "current", "Modded": "legacy"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dataDir := t.TempDir()
+			writeProfiles := func(folder string, profiles map[string]string) {
+				for name, content := range profiles {
+					dir := filepath.Join(dataDir, folder, "profiles", name)
+					if err := os.MkdirAll(dir, 0755); err != nil {
+						t.Fatal(err)
+					}
+					if err := os.WriteFile(filepath.Join(dir, "mods.yml"), []byte(content), 0644); err != nil {
+						t.Fatal(err)
+					}
+				}
+			}
+
+			writeProfiles("R.E.P.O", tt.legacy)
+			writeProfiles("REPO", tt.current)
+
+			MoveLegacyFolders(dataDir)
+
+			if got := isDir(filepath.Join(dataDir, "R.E.P.O")); got != tt.wantLegacy {
+				t.Errorf("legacy folder exists = %v, want %v", got, tt.wantLegacy)
+			}
+			if tt.wantLegacy {
+				data, err := os.ReadFile(filepath.Join(dataDir, "R.E.P.O", "profiles", "Default", "mods.yml"))
+				if err != nil || string(data) != "legacy" {
+					t.Errorf("clashing legacy profile = %q, %v", data, err)
+				}
+			}
+			for name, want := range tt.wantProfiles {
+				data, err := os.ReadFile(filepath.Join(dataDir, "REPO", "profiles", name, "mods.yml"))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if string(data) != want {
+					t.Errorf("REPO profile %s = %q, want %q", name, data, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,222 @@
+package r2modman
+
+import (
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ur-wesley/modhelper/internal"
+	"github.com/ur-wesley/modhelper/internal/logging"
+)
+
+func logger() *slog.Logger {
+	return logging.For("r2modman")
+}
+
+type ManagerKind string
+
+const (
+	KindR2Modman     ManagerKind = "r2modman"
+	KindThunderstore ManagerKind = "thunderstore"
+	KindGale         ManagerKind = "gale"
+)
+
+type Manager struct {
+	Kind       ManagerKind
+	Name       string
+	Executable string
+	DataDir    string
+	Settings   string
+}
+
+var (
+	detectOnce       sync.Once
+	detectedManagers []Manager
+
+	dataDirectoryPattern = regexp.MustCompile(`"dataDirectory"\s*:\s*"((?:[^"\\]|\\.)*)"`)
+)
+
+func Detect() []Manager {
+	detectOnce.Do(func() {
+		detectedManagers = detectManagers()
+		for _, manager := range detectedManagers {
+			logger().Info("Detected mod manager", "name", manager.Name, "executable", manager.Executable, "data", manager.DataDir)
+		}
+	})
+	return detectedManagers
+}
+
+func Preferred() Manager {
+	if managers := Detect(); len(managers) > 0 {
+		return managers[0]
+	}
+	return Manager{
+		Kind:    KindR2Modman,
+		Name:    "r2modman",
+		DataDir: defaultR2ModmanDataDir(),
+	}
+}
+
+func detectManagers() []Manager {
+	var managers []Manager
+
+	appData := os.Getenv("AppData")
+	localAppData := os.Getenv("LocalAppData")
+
+	r2 := Manager{
+		Kind:       KindR2Modman,
+		Name:       "r2modman",
+		Executable: existingPath(GetDefaultPath()),
+		DataDir:    defaultR2ModmanDataDir(),
+	}
+	if appData != "" {
+		r2.Settings = filepath.Join(appData, "r2modman", "IndexedDB")
+		if dataDir := readR2ModmanDataDirectory(r2.Settings); dataDir != "" {
+			r2.DataDir = dataDir
+		}
+	}
+	if r2.Executable != "" || isDir(r2.DataDir) {
+		managers = append(managers, r2)
+	}
+
+	if appData != "" {
+		tmm := Manager{
+			Kind:     KindThunderstore,
+			Name:     "Thunderstore Mod Manager",
+			DataDir:  filepath.Join(appData, "Thunderstore Mod Manager", "DataFolder"),
+			Settings: filepath.Join(appData, "Thunderstore Mod Manager", "IndexedDB"),
+		}
+		if dataDir := readR2ModmanDataDirectory(tmm.Settings); dataDir != "" {
+			tmm.DataDir = dataDir
+		}
+		if isDir(tmm.DataDir) {
+			managers = append(managers, tmm)
+		}
+	}
+
+	if appData != "" {
+		gale := Manager{
+			Kind:     KindGale,
+			Name:     "Gale",
+			DataDir:  filepath.Join(appData, "com.kesomannen.gale"),
+			Settings: filepath.Join(appData, "com.kesomannen.gale", "prefs.json"),
+		}
+		if localAppData != "" {
+			gale.Executable = existingPath(filepath.Join(localAppData, "Gale", "gale.exe"))
+		}
+		if dataDir := readGaleDataDirectory(gale.Settings); dataDir != "" {
+			gale.DataDir = dataDir
+		}
+		if gale.Executable != "" || isDir(gale.DataDir) {
+			managers = append(managers, gale)
+		}
+	}
+
+	return managers
+}
+
+func defaultR2ModmanDataDir() string {
+	appData := os.Getenv("AppData")
+	if appData == "" {
+		return filepath.Join(os.Getenv("HOME"), ".r2modmanPlus-local")
+	}
+	return filepath.Join(appData, "r2modmanPlus-local")
+}
+
+func readR2ModmanDataDirectory(indexedDBDir string) string {
+	var logs []string
+	filepath.WalkDir(indexedDBDir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if !d.IsDir() && (strings.HasSuffix(path, ".log") || strings.HasSuffix(path, ".ldb")) {
+			logs = append(logs, path)
+		}
+		return nil
+	})
+
+	sort.Slice(logs, func(i, j int) bool {
+		return modTime(logs[i]) < modTime(logs[j])
+	})
+
+	dataDir := ""
+	for _, path := range logs {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		for _, match := range dataDirectoryPattern.FindAllSubmatch(data, -1) {
+			value, err := strconv.Unquote(`"` + string(match[1]) + `"`)
+			if err != nil || value == "" {
+				continue
+			}
+			dataDir = value
+		}
+	}
+
+	if dataDir != "" && !isDir(dataDir) {
+		logger().Warn("Configured mod manager data folder does not exist, ignoring", "dir", dataDir)
+		return ""
+	}
+	return dataDir
+}
+
+func readGaleDataDirectory(prefsPath string) string {
+	data, err := os.ReadFile(prefsPath)
+	if err != nil {
+		return ""
+	}
+
+	var prefs map[string]any
+	if err := json.Unmarshal(data, &prefs); err != nil {
+		logger().Warn("Could not parse Gale preferences", "path", prefsPath, "error", err)
+		return ""
+	}
+
+	for _, key := range []string{"dataDir", "data_dir"} {
+		if value, ok := prefs[key].(string); ok && value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+func (m Manager) GameDir(game internal.Game) string {
+	if m.Kind == KindGale {
+		return filepath.Join(m.DataDir, galeSlug(game))
+	}
+	return filepath.Join(m.DataDir, GameFolder(game))
+}
+
+func (m Manager) ProfilesDir(game internal.Game) string {
+	return filepath.Join(m.GameDir(game), "profiles")
+}
+
+func existingPath(path string) string {
+	if path == "" {
+		return ""
+	}
+	if _, err := os.Stat(path); err != nil {
+		return ""
+	}
+	return path
+}
+
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
+
+func modTime(path string) int64 {
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0
+	}
+	return info.ModTime().UnixNano()
+}
@@ -0,0 +1,121 @@
+package r2modman
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+)
+
+func writeDataDirectorySetting(t *testing.T, settingsDir, dataDir string) {
+	t.Helper()
+	leveldb := filepath.Join(settingsDir, "file__0.indexeddb.leveldb")
+	if err := os.MkdirAll(leveldb, 0755); err != nil {
+		t.Fatal(err)
+	}
+	record := `{"dataDirectory":` + strconv.Quote(dataDir) + `}`
+	if err := os.WriteFile(filepath.Join(leveldb, "000003.log"), []byte(record), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDetectDataDir(t *testing.T) {
+	tests := []struct {
+		name          string
+		kind          ManagerKind
+		settings      string
+		defaultExists bool
+		setting       string
+		customExists  bool
+		want          string
+	}{
+		{
+			name:          "r2modman configured folder wins over existing default",
+			kind:          KindR2Modman,
+			settings:      filepath.Join("r2modman", "IndexedDB"),
+			defaultExists: true,
+			setting:       "custom",
+			customExists:  true,
+			want:          "custom",
+		},
+		{
+			name:         "r2modman configured folder without default",
+			kind:         KindR2Modman,
+			settings:     filepath.Join("r2modman", "IndexedDB"),
+			setting:      "custom",
+			customExists: true,
+			want:         "custom",
+		},
+		{
+			name:          "r2modman missing configured folder falls back to default",
+			kind:          KindR2Modman,
+			settings:      filepath.Join("r2modman", "IndexedDB"),
+			defaultExists: true,
+			setting:       "custom",
+			want:          "default",
+		},
+		{
+			name:          "r2modman default without setting",
+			kind:          KindR2Modman,
+			defaultExists: true,
+			want:          "default",
+		},
+		{
+			name:          "thunderstore configured folder wins over existing default",
+			kind:          KindThunderstore,
+			settings:      filepath.Join("Thunderstore Mod Manager", "IndexedDB"),
+			defaultExists: true,
+			setting:       "custom",
+			customExists:  true,
+			want:          "custom",
+		},
+		{
+			name:          "thunderstore default without setting",
+			kind:          KindThunderstore,
+			defaultExists: true,
+			want:          "default",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			appData := t.TempDir()
+			t.Setenv("AppData", appData)
+			t.Setenv("LocalAppData", "")
+
+			dirs := map[string]string{
+				"custom": filepath.Join(t.TempDir(), "manager-data"),
+			}
+			switch tt.kind {
+			case KindR2Modman:
+				dirs["default"] = filepath.Join(appData, "r2modmanPlus-local")
+			case KindThunderstore:
+				dirs["default"] = filepath.Join(appData, "Thunderstore Mod Manager", "DataFolder")
+			}
+			if tt.defaultExists {
+				if err := os.MkdirAll(dirs["default"], 0755); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if tt.customExists {
+				if err := os.MkdirAll(dirs["custom"], 0755); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if tt.setting != "" {
+				writeDataDirectorySetting(t, filepath.Join(appData, tt.settings), dirs[tt.setting])
+			}
+
+			for _, manager := range detectManagers() {
+				if manager.Kind != tt.kind {
+					continue
+				}
+				if manager.DataDir != dirs[tt.want] {
+					t.Errorf("data folder = %s, want %s", manager.DataDir, dirs[tt.want])
+				}
+				return
+			}
+			t.Fatalf("%s was not detected", tt.kind)
+		})
+	}
+}
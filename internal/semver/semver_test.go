@@ -0,0 +1,114 @@
+package semver
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Version
+		wantErr bool
+	}{
+		{input: "1.2.3", want: Version{Major: 1, Minor: 2, Patch: 3}},
+		{input: "v1.2.3", want: Version{Major: 1, Minor: 2, Patch: 3}},
+		{input: " V1.2.3 ", want: Version{Major: 1, Minor: 2, Patch: 3}},
+		{input: "2.1", want: Version{Major: 2, Minor: 1}},
+		{input: "2", want: Version{Major: 2}},
+		{input: "1.0.0-beta.2", want: Version{Major: 1, Prerelease: []string{"beta", "2"}}},
+		{input: "1.0.0+build.5", want: Version{Major: 1, Build: "build.5"}},
+		{input: "v1.0.0-rc.1+sha.abc", want: Version{Major: 1, Prerelease: []string{"rc", "1"}, Build: "sha.abc"}},
+		{input: "", wantErr: true},
+		{input: "v", wantErr: true},
+		{input: "1.2.3.4", wantErr: true},
+		{input: "1.x.0", wantErr: true},
+		{input: "1..0", wantErr: true},
+		{input: "1.-2.0", wantErr: true},
+		{input: "1.0.0-", wantErr: true},
+		{input: "1.0.0-beta..1", wantErr: true},
+		{input: "1.0.0+", wantErr: true},
+		{input: "latest", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := Parse(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Major != tt.want.Major || got.Minor != tt.want.Minor || got.Patch != tt.want.Patch ||
+				!slices.Equal(got.Prerelease, tt.want.Prerelease) || got.Build != tt.want.Build {
+				t.Errorf("Parse(%q) = %+v, want %+v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.0.0", "1.0.0", 0},
+		{"2.1", "2.1.0", 0},
+		{"v2.1.0", "2.1.0", 0},
+		{"1.0.0", "1.0.1", -1},
+		{"1.2.0", "1.10.0", -1},
+		{"2.0.0", "1.9.9", 1},
+		{"1.0.0-alpha", "1.0.0", -1},
+		{"1.0.0-alpha", "1.0.0-alpha.1", -1},
+		{"1.0.0-alpha.1", "1.0.0-alpha.beta", -1},
+		{"1.0.0-alpha.beta", "1.0.0-beta", -1},
+		{"1.0.0-beta", "1.0.0-beta.2", -1},
+		{"1.0.0-beta.2", "1.0.0-beta.11", -1},
+		{"1.0.0-beta.11", "1.0.0-rc.1", -1},
+		{"1.0.0-rc.1", "1.0.0", -1},
+		{"1.0.0+build.1", "1.0.0+build.2", 0},
+		{"1.0.0+build.1", "1.0.0", 0},
+		{"1.0.0-rc.1+build.1", "1.0.0-rc.1", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+" vs "+tt.b, func(t *testing.T) {
+			got, err := Compare(tt.a, tt.b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("Compare(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+			}
+			if reverse, _ := Compare(tt.b, tt.a); reverse != -tt.want {
+				t.Errorf("Compare(%q, %q) = %d, want %d", tt.b, tt.a, reverse, -tt.want)
+			}
+		})
+	}
+}
+
+func TestCompareInvalid(t *testing.T) {
+	for _, pair := range [][2]string{{"1.0.0", "latest"}, {"", "1.0.0"}, {"1.0.0-", "1.0.0"}} {
+		if _, err := Compare(pair[0], pair[1]); err == nil {
+			t.Errorf("Compare(%q, %q) succeeded, want error", pair[0], pair[1])
+		}
+	}
+}
+
+func TestString(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"v2.1", "2.1.0"},
+		{"1.0.0-beta.2", "1.0.0-beta.2"},
+		{"1.0.0-rc.1+sha.abc", "1.0.0-rc.1+sha.abc"},
+	}
+
+	for _, tt := range tests {
+		if got := MustParse(tt.input).String(); got != tt.want {
+			t.Errorf("MustParse(%q).String() = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
@@ -2,19 +2,25 @@ package steam
 
 import (
 	"fmt"
-	"log"
+	"log/slog"
+	"maps"
+	"net/url"
 	"os"
 	"os/exec"
 	"path/filepath"
-	"regexp"
 	"runtime"
+	"slices"
 	"strings"
 
 	"github.com/ur-wesley/modhelper/internal"
-	"github.com/ur-wesley/modhelper/internal/config"
+	"github.com/ur-wesley/modhelper/internal/logging"
 	"github.com/ur-wesley/modhelper/internal/profile"
 )
 
+func logger() *slog.Logger {
+	return logging.For("steam")
+}
+
 func IsGameInstalled(game internal.Game, steamApps map[string]App) bool {
 	_, exists := steamApps[game.ID]
 	return exists
@@ -58,257 +64,247 @@ func launchWithOverlay(appID string, gameArgs []string) error {
 		args = append(args, gameArgs...)
 	}
 
-	fmt.Printf("Launching Steam: %s %v\n", steamExe, args)
+	logger().Info("Launching Steam", "exe", steamExe, "args", args)
 	cmd := exec.Command(steamExe, args...)
 	return cmd.Start()
 }
 
-func launchDirectly(game internal.Game, gameArgs []string, steamApps map[string]App) error {
-	exePath, err := FindGameExeAuto(game.ID, game.Name)
+func launchSteamURL(appID string, gameArgs []string, options internal.LaunchOptions) error {
+	steamURL := "steam://run/" + appID
+	if len(gameArgs) > 0 {
+		steamURL += "//" + url.PathEscape(joinArguments(gameArgs)) + "/"
+	}
+	warnIgnoredEnv(options)
+
+	logger().Info("Launching Steam URL", "url", steamURL)
+	cmd := exec.Command("rundll32", "url.dll,FileProtocolHandler", steamURL)
+	return cmd.Start()
+}
+
+func launchDirectly(game internal.Game, gameArgs []string, options internal.LaunchOptions, vars map[string]string) error {
+	exePath, err := resolveExecutable(game, options, vars)
 	if err != nil {
 		return fmt.Errorf("failed to find game executable: %w", err)
 	}
 
-	fmt.Printf("Launching directly: %s %v\n", exePath, gameArgs)
+	logger().Info("Launching directly", "exe", exePath, "args", gameArgs, "env", envNames(options))
 	cmd := exec.Command(exePath, gameArgs...)
 	cmd.Dir = filepath.Dir(exePath)
+	cmd.Env = launchEnv(options, vars)
 	return cmd.Start()
 }
 
-func LaunchGame(game internal.Game, targetDir string, steamApps map[string]App) error {
-	_, exists := steamApps[game.ID]
-	if !exists {
+func launchCommand(game internal.Game, gameArgs []string, options internal.LaunchOptions, vars map[string]string) error {
+	parts := parseArguments(options.Command)
+	if len(parts) == 0 {
+		return fmt.Errorf("no launch command configured for %s", game.Name)
+	}
+
+	if strings.Contains(options.Command, "${exe}") {
+		exePath, err := resolveExecutable(game, options, vars)
+		if err != nil {
+			return fmt.Errorf("failed to find game executable: %w", err)
+		}
+		vars["exe"] = exePath
+	}
+
+	var args []string
+	argsUsed := false
+	for _, part := range parts {
+		if part == "${args}" {
+			args = append(args, gameArgs...)
+			argsUsed = true
+			continue
+		}
+		args = append(args, expandPlaceholders(part, vars))
+	}
+	if !argsUsed {
+		args = append(args, gameArgs...)
+	}
+
+	logger().Info("Launching custom command", "command", args[0], "args", args[1:], "env", envNames(options))
+	cmd := exec.Command(args[0], args[1:]...)
+	if vars["gameDir"] != "" {
+		cmd.Dir = vars["gameDir"]
+	}
+	cmd.Env = launchEnv(options, vars)
+	return cmd.Start()
+}
+
+func LaunchGame(store *profile.ProfileStore, game internal.Game, steamApps map[string]App, options internal.LaunchOptions) error {
+	app, exists := steamApps[game.ID]
+	if !exists && !IsLaunchable(game, steamApps, options) {
 		return fmt.Errorf("game not installed: %s (ID: %s)", game.Name, game.ID)
 	}
 
-	profileInstalled := profile.IsInstalled(game, targetDir)
+	vars := map[string]string{
+		"appId":   game.ID,
+		"gameDir": app.Path,
+	}
+
+	profileInstalled := store.IsInstalled(game)
+	if profileInstalled {
+		vars["profileLoc"] = store.GameDir(game)
+		vars["profileName"] = profile.GetProfileName(game)
+	}
+
 	var gameArgs []string
 	if profileInstalled && game.LaunchArgs != "" {
-		gameProfileDir := config.GetGameProfileDir(game)
-		profileName := profile.GetActualProfileName(game)
-		if profileName == "" {
-			profileName = "Default"
-		}
+		gameProfileDir := vars["profileLoc"]
+		profileName := vars["profileName"]
 
-		log.Printf("=== Launch Debug Info for %s ===", game.Name)
-		log.Printf("Game Profile Dir: %s", gameProfileDir)
-		log.Printf("Profile Name from manifest: '%s'", profileName)
-		log.Printf("Profile Name from game object: '%s'", game.ProfileName)
-
-		fullProfilePath := filepath.Join(gameProfileDir, profileName)
-		log.Printf("Full profile path: %s", fullProfilePath)
-		if _, err := os.Stat(fullProfilePath); os.IsNotExist(err) {
-			log.Printf("WARNING: Profile directory does not exist: %s", fullProfilePath)
-
-			if entries, err := os.ReadDir(gameProfileDir); err == nil {
-				log.Printf("Available profiles in %s:", gameProfileDir)
-				for _, entry := range entries {
-					if entry.IsDir() {
-						log.Printf("  - %s", entry.Name())
-					}
-				}
-			}
-		}
+		log := logger().With("game", game.Name)
+		log.Debug("Preparing profile launch", "profileDir", gameProfileDir, "profile", profileName, "gameProfile", game.ProfileName)
 
 		launchArgs := game.LaunchArgs
 		launchArgs = strings.ReplaceAll(launchArgs, "${profileLoc}", gameProfileDir)
 		launchArgs = strings.ReplaceAll(launchArgs, "${profileName}", profileName)
 		launchArgs = strings.ReplaceAll(launchArgs, "/", "\\")
 
-		log.Printf("Final launch args: %s", launchArgs)
+		log.Info("Launching with profile", "args", launchArgs, "strategy", options.Strategy)
 
 		gameArgs = parseArguments(launchArgs)
 	} else {
-		log.Printf("Launching %s without profile via Steam", game.Name)
+		if game.LaunchArgs != "" {
+			available, err := store.ListProfiles(game)
+			if err != nil && !os.IsNotExist(err) {
+				logger().Debug("Could not list profiles", "game", game.Name, "error", err)
+			}
+			logger().Warn("Profile is not installed", "game", game.Name, "path", store.ProfilePath(game), "available", available)
+		}
+		logger().Info("Launching without profile", "game", game.Name, "strategy", options.Strategy)
 	}
 
+	switch options.Strategy {
+	case internal.LaunchSteamURL:
+		return launchSteamURL(game.ID, gameArgs, options)
+	case internal.LaunchDirect:
+		return launchDirectly(game, gameArgs, options, vars)
+	case internal.LaunchCommand:
+		return launchCommand(game, gameArgs, options, vars)
+	}
+	warnIgnoredEnv(options)
 	return launchWithOverlay(game.ID, gameArgs)
 }
 
-func findGameExecutable(gamePath, gameName string) (string, error) {
-	fmt.Printf("Looking for executable in: %s\n", gamePath)
-
-	if _, err := os.Stat(gamePath); os.IsNotExist(err) {
-		return "", fmt.Errorf("game directory does not exist: %s", gamePath)
-	}
-
-	patterns := []string{
-		gameName + ".exe",
-		strings.ReplaceAll(gameName, " ", "") + ".exe",
-		strings.ReplaceAll(gameName, " ", "_") + ".exe",
-		strings.ReplaceAll(gameName, ".", "") + ".exe",
-	}
-
-	switch gameName {
-	case "Lethal Company":
-		patterns = append(patterns, "LethalCompany.exe")
-	case "R.E.P.O.":
-		patterns = append(patterns, "R.E.P.O.exe", "REPO.exe")
+func IsLaunchable(game internal.Game, steamApps map[string]App, options internal.LaunchOptions) bool {
+	if IsGameInstalled(game, steamApps) {
+		return true
 	}
 
-	fmt.Printf("Trying patterns: %v\n", patterns)
-
-	for _, pattern := range patterns {
-		exePath := filepath.Join(gamePath, pattern)
-		if _, err := os.Stat(exePath); err == nil {
-			return exePath, nil
+	switch options.Strategy {
+	case internal.LaunchDirect:
+		if !filepath.IsAbs(options.Executable) {
+			return false
 		}
+		_, err := os.Stat(options.Executable)
+		return err == nil
+	case internal.LaunchCommand:
+		return strings.TrimSpace(options.Command) != "" &&
+			!strings.Contains(options.Command, "${gameDir}") &&
+			(!strings.Contains(options.Command, "${exe}") || filepath.IsAbs(options.Executable))
 	}
+	return false
+}
 
-	files, err := os.ReadDir(gamePath)
-	if err != nil {
-		return "", fmt.Errorf("cannot read game directory: %w", err)
-	}
-
-	fmt.Printf("Scanning directory for .exe files...\n")
-	var foundExes []string
+func resolveExecutable(game internal.Game, options internal.LaunchOptions, vars map[string]string) (string, error) {
+	gameDir := vars["gameDir"]
 
-	for _, file := range files {
-		if !file.IsDir() && strings.HasSuffix(strings.ToLower(file.Name()), ".exe") {
-			foundExes = append(foundExes, file.Name())
-			name := strings.ToLower(file.Name())
-			if strings.Contains(name, "unins") || strings.Contains(name, "setup") ||
-				strings.Contains(name, "redist") || strings.Contains(name, "vcredist") {
-				continue
+	if options.Executable != "" {
+		exePath := expandPlaceholders(options.Executable, vars)
+		if !filepath.IsAbs(exePath) {
+			if gameDir == "" {
+				return "", fmt.Errorf("install folder of %s is unknown", game.Name)
 			}
-			return filepath.Join(gamePath, file.Name()), nil
+			exePath = filepath.Join(gameDir, exePath)
 		}
+		if _, err := os.Stat(exePath); err != nil {
+			return "", err
+		}
+		return exePath, nil
 	}
 
-	if len(foundExes) > 0 {
-		return "", fmt.Errorf("no suitable executable found in %s (found: %v)", gamePath, foundExes)
+	if gameDir == "" {
+		return "", fmt.Errorf("install folder of %s is unknown", game.Name)
 	}
-
-	return "", fmt.Errorf("no executable found in %s", gamePath)
+	return findGameExecutable(gameDir, game)
 }
 
-func findGameExecutableAuto(gamePath, gameName, gameID string) (string, error) {
-	fmt.Printf("Looking for executable in: %s\n", gamePath)
-
-	if _, err := os.Stat(gamePath); os.IsNotExist(err) {
-		return "", fmt.Errorf("game directory does not exist: %s", gamePath)
-	}
-
-	patterns := []string{
-		gameName + ".exe",
-		strings.ReplaceAll(gameName, " ", "") + ".exe",
-		strings.ReplaceAll(gameName, " ", "_") + ".exe",
-		strings.ReplaceAll(gameName, ".", "") + ".exe",
-	}
-
-
-	fmt.Printf("Trying patterns: %v\n", patterns)
+func findGameExecutable(gameDir string, game internal.Game) (string, error) {
+	patterns := getExecutablePatterns(game)
+	logger().Debug("Trying executable patterns", "path", gameDir, "patterns", patterns)
 
 	for _, pattern := range patterns {
-		exePath := filepath.Join(gamePath, pattern)
+		exePath := filepath.Join(gameDir, pattern)
 		if _, err := os.Stat(exePath); err == nil {
+			logger().Info("Found executable", "path", exePath)
 			return exePath, nil
 		}
 	}
 
-	files, err := os.ReadDir(gamePath)
+	files, err := os.ReadDir(gameDir)
 	if err != nil {
 		return "", fmt.Errorf("cannot read game directory: %w", err)
 	}
 
-	fmt.Printf("Scanning directory for .exe files...\n")
+	logger().Debug("Scanning directory for executables", "path", gameDir)
 	var foundExes []string
-
 	for _, file := range files {
-		if !file.IsDir() && strings.HasSuffix(strings.ToLower(file.Name()), ".exe") {
-			foundExes = append(foundExes, file.Name())
-			name := strings.ToLower(file.Name())
-			if strings.Contains(name, "unins") || strings.Contains(name, "setup") ||
-				strings.Contains(name, "redist") || strings.Contains(name, "vcredist") {
-				continue
-			}
-			return filepath.Join(gamePath, file.Name()), nil
+		if file.IsDir() || !strings.HasSuffix(strings.ToLower(file.Name()), ".exe") {
+			continue
+		}
+		foundExes = append(foundExes, file.Name())
+		name := strings.ToLower(file.Name())
+		if strings.Contains(name, "unins") || strings.Contains(name, "setup") ||
+			strings.Contains(name, "redist") || strings.Contains(name, "vcredist") ||
+			strings.Contains(name, "crashhandler") {
+			continue
 		}
+		exePath := filepath.Join(gameDir, file.Name())
+		logger().Info("Found fallback executable", "path", exePath)
+		return exePath, nil
 	}
 
 	if len(foundExes) > 0 {
-		return "", fmt.Errorf("no suitable executable found in %s (found: %v)", gamePath, foundExes)
+		return "", fmt.Errorf("no suitable executable found in %s (found: %v)", gameDir, foundExes)
 	}
-
-	return "", fmt.Errorf("no executable found in %s", gamePath)
+	return "", fmt.Errorf("no executable found in %s", gameDir)
 }
 
-func FindGameExeAuto(appID string, gameName string) (string, error) {
-	steamPath, err := getSteamPath()
-	if err != nil {
-		return "", err
+func launchEnv(options internal.LaunchOptions, vars map[string]string) []string {
+	env := os.Environ()
+	for _, key := range slices.Sorted(maps.Keys(options.Env)) {
+		env = append(env, key+"="+expandPlaceholders(options.Env[key], vars))
 	}
+	return env
+}
 
-	libs := []string{filepath.Join(steamPath, "steamapps")}
-	libFile := filepath.Join(steamPath, "steamapps", "libraryfolders.vdf")
-	if data, err := os.ReadFile(libFile); err == nil {
-		for _, line := range strings.Split(string(data), "\n") {
-			if strings.Contains(line, `"path"`) {
-				parts := strings.Split(line, `"`)
-				if len(parts) >= 4 {
-					libs = append(libs, filepath.Join(parts[3], "steamapps"))
-				}
-			}
-		}
-	}
-
-	manifestName := fmt.Sprintf("appmanifest_%s.acf", appID)
-	for _, lib := range libs {
-		manifest := filepath.Join(lib, manifestName)
-		if _, err := os.Stat(manifest); err == nil {
-			data, err := os.ReadFile(manifest)
-			if err != nil {
-				continue
-			}
-
-			re := regexp.MustCompile(`"installdir"\s+"(.+)"`)
-			matches := re.FindStringSubmatch(string(data))
-			if len(matches) < 2 {
-				continue
-			}
-
-			installDir := matches[1]
-			gameDir := filepath.Join(lib, "common", installDir)
-
-			fmt.Printf("Found game directory: %s\n", gameDir)
-
-			patterns := []string{
-				gameName + ".exe",
-				strings.ReplaceAll(gameName, " ", "") + ".exe",
-				strings.ReplaceAll(gameName, ".", "") + ".exe",
-			}
-
-			fmt.Printf("Trying executable patterns: %v\n", patterns)
+func envNames(options internal.LaunchOptions) []string {
+	return slices.Sorted(maps.Keys(options.Env))
+}
 
-			for _, pattern := range patterns {
-				exePath := filepath.Join(gameDir, pattern)
-				if _, err := os.Stat(exePath); err == nil {
-					fmt.Printf("Found executable: %s\n", exePath)
-					return exePath, nil
-				}
-			}
+func warnIgnoredEnv(options internal.LaunchOptions) {
+	if len(options.Env) > 0 {
+		logger().Warn("Environment variables only apply to direct and command launches, set them in the Steam launch options instead", "env", envNames(options))
+	}
+}
 
-			files, err := os.ReadDir(gameDir)
-			if err != nil {
-				continue
-			}
+func expandPlaceholders(value string, vars map[string]string) string {
+	for name, replacement := range vars {
+		value = strings.ReplaceAll(value, "${"+name+"}", replacement)
+	}
+	return value
+}
 
-			fmt.Printf("Scanning directory for executables...\n")
-			for _, file := range files {
-				if !file.IsDir() && strings.HasSuffix(strings.ToLower(file.Name()), ".exe") {
-					name := strings.ToLower(file.Name())
-					if strings.Contains(name, "unins") || strings.Contains(name, "setup") ||
-						strings.Contains(name, "redist") || strings.Contains(name, "vcredist") {
-						continue
-					}
-					exePath := filepath.Join(gameDir, file.Name())
-					fmt.Printf("Found fallback executable: %s\n", exePath)
-					return exePath, nil
-				}
-			}
+func joinArguments(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		if strings.ContainsAny(arg, " \t") {
+			arg = `"` + arg + `"`
 		}
+		quoted[i] = arg
 	}
-	return "", fmt.Errorf("game %s (ID: %s) executable not found in any Steam library", gameName, appID)
+	return strings.Join(quoted, " ")
 }
 
 func parseArguments(cmdLine string) []string {
@@ -0,0 +1,65 @@
+package steam
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseArguments(t *testing.T) {
+	tests := []struct {
+		input string
+		want  []string
+	}{
+		{"", nil},
+		{"   ", nil},
+		{"--doorstop-enable true", []string{"--doorstop-enable", "true"}},
+		{"  a \t b  ", []string{"a", "b"}},
+		{`--profile "C:\Users\Test User\profiles\Default"`, []string{"--profile", `C:\Users\Test User\profiles\Default`}},
+		{`a"b c"d`, []string{"ab cd"}},
+		{`"unterminated quote`, []string{"unterminated quote"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := parseArguments(tt.input); !slices.Equal(got, tt.want) {
+				t.Errorf("parseArguments(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpandPlaceholders(t *testing.T) {
+	vars := map[string]string{
+		"appId":       "3241660",
+		"gameDir":     `C:\Steam\steamapps\common\REPO`,
+		"profileLoc":  `C:\r2\REPO\profiles`,
+		"profileName": "Default",
+	}
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"no placeholders", "no placeholders"},
+		{"${appId}", "3241660"},
+		{`${gameDir}\REPO.exe`, `C:\Steam\steamapps\common\REPO\REPO.exe`},
+		{`${profileLoc}\${profileName} ${profileName}`, `C:\r2\REPO\profiles\Default Default`},
+		{"${unknown} stays", "${unknown} stays"},
+		{"$appId {appId}", "$appId {appId}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := expandPlaceholders(tt.input, vars); got != tt.want {
+				t.Errorf("expandPlaceholders(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJoinArgumentsRoundTrip(t *testing.T) {
+	args := []string{"--doorstop-enable", "true", "--doorstop-target", `C:\Users\Test User\BepInEx\core\BepInEx.Preloader.dll`}
+	if got := parseArguments(joinArguments(args)); !slices.Equal(got, args) {
+		t.Errorf("parseArguments(joinArguments()) = %q, want %q", got, args)
+	}
+}
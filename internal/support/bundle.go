@@ -0,0 +1,253 @@
+package support
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/ur-wesley/modhelper/internal"
+	"github.com/ur-wesley/modhelper/internal/config"
+	"github.com/ur-wesley/modhelper/internal/logging"
+	"github.com/ur-wesley/modhelper/internal/profile"
+)
+
+const maxLogSize = 5 << 20
+
+var unsafeNameChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+func BundleName(now time.Time) string {
+	return fmt.Sprintf("modhelper-support-%s.zip", now.Format("20060102-150405"))
+}
+
+func WriteBundle(w io.Writer, cfg *internal.Config, store *profile.ProfileStore) error {
+	zw := zip.NewWriter(w)
+	redact := homeRedactor()
+
+	if err := writeEntry(zw, "info.txt", []byte(systemInfo(redact))); err != nil {
+		return err
+	}
+
+	configData, err := json.MarshalIndent(sanitizeConfig(cfg, redact), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal config: %w", err)
+	}
+	if err := writeEntry(zw, "config.json", configData); err != nil {
+		return err
+	}
+
+	if err := addLogs(zw, redact); err != nil {
+		return err
+	}
+
+	var games []internal.Game
+	manifest, err := profile.FetchManifest(cfg.ManifestURL, config.GetHTTPTimeout(cfg))
+	if err != nil {
+		logging.For("support").Warn("Could not include manifest in support bundle", "error", err)
+		if err := writeEntry(zw, "manifest_error.txt", []byte(redact(err.Error()))); err != nil {
+			return err
+		}
+
+		games, err = store.InstalledGames()
+		if err != nil {
+			logging.For("support").Warn("Could not list installed profiles for support bundle", "error", err)
+		}
+	} else {
+		manifestData, err := json.MarshalIndent(manifest, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal manifest: %w", err)
+		}
+		if err := writeEntry(zw, "manifest.json", manifestData); err != nil {
+			return err
+		}
+		games = manifest.Games
+	}
+
+	if err := addProfiles(zw, store, games, redact); err != nil {
+		return err
+	}
+
+	return zw.Close()
+}
+
+func addLogs(zw *zip.Writer, redact func(string) string) error {
+	dir, err := logging.Dir()
+	if err != nil {
+		return nil
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		data, err := readTail(filepath.Join(dir, entry.Name()), maxLogSize)
+		if err != nil {
+			continue
+		}
+		if err := writeEntry(zw, "logs/"+entry.Name(), []byte(redact(string(data)))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func addProfiles(zw *zip.Writer, store *profile.ProfileStore, games []internal.Game, redact func(string) string) error {
+	seen := make(map[string]bool)
+
+	for _, baseGame := range games {
+		for _, variant := range baseGame.ProfileVariants() {
+			game := baseGame.WithVariant(variant)
+			profilePath := store.ProfilePath(game)
+			if seen[profilePath] {
+				continue
+			}
+			seen[profilePath] = true
+
+			dir := "profiles/" + safeName(game.Name)
+			if game.Variant != "" {
+				dir += "-" + safeName(game.Variant)
+			}
+
+			if report, err := store.LoadInstallReport(game); err == nil {
+				data, err := json.MarshalIndent(report, "", "  ")
+				if err != nil {
+					return fmt.Errorf("failed to marshal install report: %w", err)
+				}
+				if err := writeEntry(zw, dir+"/install_report.json", data); err != nil {
+					return err
+				}
+			}
+
+			if version, err := store.GetInstalledProfileVersion(game); err == nil && version != "" {
+				if err := writeEntry(zw, dir+"/version.txt", []byte(version+"\n")); err != nil {
+					return err
+				}
+			}
+
+			logData, err := store.FS().ReadFile(filepath.Join(profilePath, "BepInEx", "LogOutput.log"))
+			if err == nil {
+				if len(logData) > maxLogSize {
+					logData = logData[len(logData)-maxLogSize:]
+				}
+				if err := writeEntry(zw, dir+"/LogOutput.log", []byte(redact(string(logData)))); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func sanitizeConfig(cfg *internal.Config, redact func(string) string) internal.Config {
+	sanitized := *cfg
+	sanitized.ManifestURL = sanitizeURL(sanitized.ManifestURL)
+	sanitized.ThunderstoreURL = sanitizeURL(sanitized.ThunderstoreURL)
+	sanitized.UpdateFeedURL = sanitizeURL(sanitized.UpdateFeedURL)
+	sanitized.TargetDir = redact(sanitized.TargetDir)
+
+	if len(cfg.GameLaunch) > 0 {
+		sanitized.GameLaunch = make(map[string]internal.LaunchOptions, len(cfg.GameLaunch))
+		for game, options := range cfg.GameLaunch {
+			options.Executable = redact(options.Executable)
+			options.Command = redact(options.Command)
+			if len(options.Env) > 0 {
+				env := make(map[string]string, len(options.Env))
+				for name, value := range options.Env {
+					env[name] = redact(value)
+				}
+				options.Env = env
+			}
+			sanitized.GameLaunch[game] = options
+		}
+	}
+	return sanitized
+}
+
+func sanitizeURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return raw
+	}
+	if u.User != nil {
+		u.User = url.User("redacted")
+	}
+	if u.RawQuery != "" {
+		u.RawQuery = "redacted"
+	}
+	return u.String()
+}
+
+func homeRedactor() func(string) string {
+	home, err := os.UserHomeDir()
+	if err != nil || home == "" {
+		return func(s string) string { return s }
+	}
+
+	replacer := strings.NewReplacer(home, "~", filepath.ToSlash(home), "~")
+	return replacer.Replace
+}
+
+func systemInfo(redact func(string) string) string {
+	var info bytes.Buffer
+	fmt.Fprintf(&info, "app: %s %s\n", internal.AppName, internal.AppVersion)
+	fmt.Fprintf(&info, "os: %s/%s\n", runtime.GOOS, runtime.GOARCH)
+	fmt.Fprintf(&info, "go: %s\n", runtime.Version())
+	fmt.Fprintf(&info, "created: %s\n", time.Now().Format(time.RFC3339))
+	if exe, err := os.Executable(); err == nil {
+		fmt.Fprintf(&info, "executable: %s\n", redact(exe))
+	}
+	if path, err := config.Path(); err == nil {
+		fmt.Fprintf(&info, "config: %s\n", redact(path))
+	}
+	if dir, err := logging.Dir(); err == nil {
+		fmt.Fprintf(&info, "logs: %s\n", redact(dir))
+	}
+	return info.String()
+}
+
+func readTail(path string, limit int64) ([]byte, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if info.Size() > limit {
+		if _, err := file.Seek(info.Size()-limit, io.SeekStart); err != nil {
+			return nil, err
+		}
+	}
+	return io.ReadAll(file)
+}
+
+func safeName(name string) string {
+	return strings.Trim(unsafeNameChars.ReplaceAllString(name, "_"), "_")
+}
+
+func writeEntry(zw *zip.Writer, name string, data []byte) error {
+	w, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: time.Now()})
+	if err != nil {
+		return fmt.Errorf("failed to add %s to support bundle: %w", name, err)
+	}
+	if _, err := w.Write(data); err != nil {
+		return fmt.Errorf("failed to add %s to support bundle: %w", name, err)
+	}
+	return nil
+}
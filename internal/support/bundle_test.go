@@ -0,0 +1,103 @@
+package support
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ur-wesley/modhelper/internal"
+	"github.com/ur-wesley/modhelper/internal/profile"
+	"github.com/ur-wesley/modhelper/internal/r2modman"
+)
+
+func TestWriteBundleOffline(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	fsys := profile.NewMemFS()
+	profilePath := filepath.Join("/data", "REPO", "profiles", "Modded")
+	files := map[string]string{
+		".profile_version":           `{"url":"https://example.com/repo.r2z","version":"1.2.0"}`,
+		".install_report.json":       `{"game":"R.E.P.O.","profileName":"Modded","mods":[]}`,
+		"BepInEx/LogOutput.log":      "[Info] loaded from " + filepath.Join(home, "game") + "\n",
+		"BepInEx/config/BepInEx.cfg": "[Logging]\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(profilePath, filepath.FromSlash(name))
+		if err := fsys.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := fsys.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	store := profile.NewProfileStoreWithFS("/data", r2modman.Manager{Kind: r2modman.KindR2Modman}, fsys)
+
+	cfg := &internal.Config{
+		ManifestURL: server.URL + "/manifest.json",
+		GameLaunch: map[string]internal.LaunchOptions{
+			"3241660": {
+				Strategy: internal.LaunchCommand,
+				Command:  filepath.Join(home, "bin", "launch.sh") + " {args}",
+				Env:      map[string]string{"WINEPREFIX": filepath.Join(home, ".wine")},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteBundle(&buf, cfg, store); err != nil {
+		t.Fatal(err)
+	}
+
+	reader, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries := make(map[string]string)
+	for _, file := range reader.File {
+		rc, err := file.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		entries[file.Name] = string(data)
+	}
+
+	for _, name := range []string{
+		"manifest_error.txt",
+		"profiles/REPO-Modded/install_report.json",
+		"profiles/REPO-Modded/version.txt",
+		"profiles/REPO-Modded/LogOutput.log",
+	} {
+		if _, ok := entries[name]; !ok {
+			t.Errorf("bundle is missing %s", name)
+		}
+	}
+	if got := entries["profiles/REPO-Modded/version.txt"]; got != "1.2.0\n" {
+		t.Errorf("version.txt = %q", got)
+	}
+
+	for name, content := range entries {
+		if strings.Contains(content, home) {
+			t.Errorf("%s contains the home folder: %s", name, content)
+		}
+	}
+	if !strings.Contains(entries["config.json"], "~/.wine") || !strings.Contains(entries["config.json"], "~/bin/launch.sh") {
+		t.Errorf("config.json launch options were not redacted: %s", entries["config.json"])
+	}
+}
@@ -0,0 +1,181 @@
+package thunderstore
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ur-wesley/modhelper/internal"
+	"github.com/ur-wesley/modhelper/internal/logging"
+)
+
+func logger() *slog.Logger {
+	return logging.For("thunderstore")
+}
+
+const DefaultBaseURL = "https://thunderstore.io"
+
+type Client struct {
+	BaseURL             string
+	UserAgent           string
+	HTTPClient          *http.Client
+	Limiter             *RateLimiter
+	Retry               RetryPolicy
+	RejectDeprecated    bool
+	DisableExperimental bool
+
+	mu                 sync.Mutex
+	packages           map[string][]Package
+	experimentalServed bool
+}
+
+func NewClient(baseURL string) *Client {
+	baseURL = strings.TrimRight(strings.TrimSpace(baseURL), "/")
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+
+	return &Client{
+		BaseURL:    baseURL,
+		UserAgent:  "modhelper/" + internal.AppVersion,
+		HTTPClient: &http.Client{Timeout: 60 * time.Second},
+		Limiter:    NewRateLimiter(2),
+		Retry:      DefaultRetryPolicy,
+		packages:   make(map[string][]Package),
+	}
+}
+
+func (c *Client) Packages(community string) ([]Package, error) {
+	c.mu.Lock()
+	cached, ok := c.packages[community]
+	c.mu.Unlock()
+	if ok {
+		return cached, nil
+	}
+
+	data, err := c.fetch(http.MethodGet, fmt.Sprintf("%s/c/%s/api/v1/package/", c.BaseURL, url.PathEscape(community)), nil, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch packages for %s: %w", community, err)
+	}
+
+	var packages []Package
+	if err := json.Unmarshal(data, &packages); err != nil {
+		return nil, fmt.Errorf("failed to parse packages response for %s: %w", community, err)
+	}
+	for i := range packages {
+		if len(packages[i].Versions) > 0 {
+			packages[i].Latest = &packages[i].Versions[0]
+		}
+	}
+
+	c.mu.Lock()
+	if c.packages == nil {
+		c.packages = make(map[string][]Package)
+	}
+	c.packages[community] = packages
+	c.mu.Unlock()
+
+	logger().Info("Fetched packages", "community", community, "count", len(packages))
+	return packages, nil
+}
+
+func (c *Client) PackageURL(community, fullName string) string {
+	namespace, name, _ := strings.Cut(fullName, "-")
+	return fmt.Sprintf("%s/c/%s/p/%s/%s/", c.BaseURL, url.PathEscape(community), url.PathEscape(namespace), url.PathEscape(name))
+}
+
+func (c *Client) Download(downloadURL string) ([]byte, error) {
+	return c.fetch(http.MethodGet, downloadURL, nil, "")
+}
+
+func (c *Client) fetch(method, requestURL string, body []byte, contentType string) ([]byte, error) {
+	for attempt := 1; ; attempt++ {
+		data, err := c.fetchOnce(method, requestURL, body, contentType)
+		if err == nil {
+			return data, nil
+		}
+
+		if !isRetryable(method, err) || attempt >= c.Retry.MaxAttempts {
+			if attempt > 1 {
+				return nil, fmt.Errorf("failed after %d attempts: %w", attempt, err)
+			}
+			return nil, err
+		}
+
+		var retryAfter time.Duration
+		var statusErr *StatusError
+		if errors.As(err, &statusErr) {
+			retryAfter = statusErr.RetryAfter
+		}
+		delay := c.Retry.Delay(attempt, retryAfter)
+		logger().Warn("Request failed, retrying", "url", requestURL, "error", err, "delay", delay, "attempt", attempt+1, "max_attempts", c.Retry.MaxAttempts)
+		time.Sleep(delay)
+	}
+}
+
+func (c *Client) fetchOnce(method, requestURL string, body []byte, contentType string) ([]byte, error) {
+	c.Limiter.Wait()
+
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequest(method, requestURL, reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if c.UserAgent != "" {
+		req.Header.Set("User-Agent", c.UserAgent)
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	client := c.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, &StatusError{
+			Method:     method,
+			URL:        requestURL,
+			StatusCode: resp.StatusCode,
+			RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+		}
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response from %s: %w", requestURL, err)
+	}
+	return data, nil
+}
+
+func parseRetryAfter(value string) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return time.Until(at)
+	}
+	return 0
+}
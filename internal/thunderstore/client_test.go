@@ -0,0 +1,161 @@
+package thunderstore
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type fakeThunderstore struct {
+	server   *httptest.Server
+	requests atomic.Int32
+}
+
+func newFakeThunderstore(t *testing.T, handler func(w http.ResponseWriter, r *http.Request, attempt int)) (*fakeThunderstore, *Client) {
+	t.Helper()
+
+	fake := &fakeThunderstore{}
+	fake.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handler(w, r, int(fake.requests.Add(1)))
+	}))
+	t.Cleanup(fake.server.Close)
+
+	client := NewClient(fake.server.URL)
+	client.Limiter = NewRateLimiter(0)
+	client.Retry = RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 10 * time.Millisecond}
+	return fake, client
+}
+
+func TestFetchRetries(t *testing.T) {
+	tests := []struct {
+		name         string
+		statuses     []int
+		wantErr      bool
+		wantRequests int32
+	}{
+		{name: "server errors then success", statuses: []int{503, 500, 200}, wantRequests: 3},
+		{name: "server errors exhaust attempts", statuses: []int{502, 502, 502, 200}, wantErr: true, wantRequests: 3},
+		{name: "not found is not retried", statuses: []int{404, 200}, wantErr: true, wantRequests: 1},
+		{name: "bad request is not retried", statuses: []int{400, 200}, wantErr: true, wantRequests: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake, client := newFakeThunderstore(t, func(w http.ResponseWriter, r *http.Request, attempt int) {
+				w.WriteHeader(tt.statuses[attempt-1])
+				w.Write([]byte("ok"))
+			})
+
+			_, err := client.Download(fake.server.URL + "/package/download/")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Download() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := fake.requests.Load(); got != tt.wantRequests {
+				t.Errorf("requests = %d, want %d", got, tt.wantRequests)
+			}
+		})
+	}
+}
+
+func TestFetchRetriesTimeouts(t *testing.T) {
+	fake, client := newFakeThunderstore(t, func(w http.ResponseWriter, r *http.Request, attempt int) {
+		if attempt == 1 {
+			time.Sleep(200 * time.Millisecond)
+		}
+		w.Write([]byte("ok"))
+	})
+	client.HTTPClient.Timeout = 50 * time.Millisecond
+
+	if _, err := client.Download(fake.server.URL + "/package/download/"); err != nil {
+		t.Fatalf("Download() = %v, want success after retrying the timeout", err)
+	}
+	if got := fake.requests.Load(); got != 2 {
+		t.Errorf("requests = %d, want 2", got)
+	}
+}
+
+func TestCreateLegacyProfileIsNotRetried(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler func(w http.ResponseWriter, r *http.Request, attempt int)
+		timeout time.Duration
+	}{
+		{
+			name: "server error",
+			handler: func(w http.ResponseWriter, r *http.Request, attempt int) {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			},
+		},
+		{
+			name: "rate limited",
+			handler: func(w http.ResponseWriter, r *http.Request, attempt int) {
+				w.WriteHeader(http.StatusTooManyRequests)
+			},
+		},
+		{
+			name: "response timeout",
+			handler: func(w http.ResponseWriter, r *http.Request, attempt int) {
+				time.Sleep(200 * time.Millisecond)
+				w.Write([]byte(`{"key":"late"}`))
+			},
+			timeout: 50 * time.Millisecond,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake, client := newFakeThunderstore(t, tt.handler)
+			if tt.timeout > 0 {
+				client.HTTPClient.Timeout = tt.timeout
+			}
+
+			if _, err := client.CreateLegacyProfile([]byte("profile")); err == nil {
+				t.Fatal("CreateLegacyProfile() succeeded, want error")
+			}
+			if got := fake.requests.Load(); got != 1 {
+				t.Errorf("requests = %d, want 1", got)
+			}
+		})
+	}
+}
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func TestIsRetryable(t *testing.T) {
+	dialTimeout := &net.OpError{Op: "dial", Net: "tcp", Err: timeoutError{}}
+	readTimeout := &net.OpError{Op: "read", Net: "tcp", Err: timeoutError{}}
+	refused := &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+
+	tests := []struct {
+		name   string
+		method string
+		err    error
+		want   bool
+	}{
+		{"plain error", http.MethodGet, errors.New("boom"), false},
+		{"connection refused", http.MethodGet, refused, false},
+		{"read timeout", http.MethodGet, readTimeout, true},
+		{"rate limited", http.MethodGet, &StatusError{StatusCode: http.StatusTooManyRequests}, true},
+		{"server error", http.MethodGet, &StatusError{StatusCode: http.StatusBadGateway}, true},
+		{"not found", http.MethodGet, &StatusError{StatusCode: http.StatusNotFound}, false},
+		{"post server error", http.MethodPost, &StatusError{StatusCode: http.StatusBadGateway}, false},
+		{"post read timeout", http.MethodPost, readTimeout, false},
+		{"post dial timeout", http.MethodPost, dialTimeout, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isRetryable(tt.method, tt.err); got != tt.want {
+				t.Errorf("isRetryable(%s, %v) = %v, want %v", tt.method, tt.err, got, tt.want)
+			}
+		})
+	}
+}
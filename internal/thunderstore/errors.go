@@ -0,0 +1,77 @@
+package thunderstore
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
+var (
+	ErrNotFound    = errors.New("not found on Thunderstore")
+	ErrRateLimited = errors.New("rate limited by Thunderstore")
+	ErrDeprecated  = errors.New("package is deprecated")
+)
+
+type StatusError struct {
+	Method     string
+	URL        string
+	StatusCode int
+	RetryAfter time.Duration
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%s %s failed with status %d", e.Method, e.URL, e.StatusCode)
+}
+
+func (e *StatusError) Unwrap() error {
+	switch e.StatusCode {
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusTooManyRequests:
+		return ErrRateLimited
+	}
+	return nil
+}
+
+type PackageError struct {
+	Community string
+	FullName  string
+	Err       error
+}
+
+func (e *PackageError) Error() string {
+	return fmt.Sprintf("package %s in community %s: %v", e.FullName, e.Community, e.Err)
+}
+
+func (e *PackageError) Unwrap() error {
+	return e.Err
+}
+
+func isRetryable(method string, err error) bool {
+	if method != http.MethodGet && method != http.MethodHead {
+		return isDialError(err) && isTransient(err)
+	}
+
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode == http.StatusTooManyRequests || statusErr.StatusCode >= http.StatusInternalServerError
+	}
+	return isTransient(err)
+}
+
+func isTransient(err error) bool {
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	var temporary interface{ Temporary() bool }
+	return errors.As(err, &temporary) && temporary.Temporary()
+}
+
+func isDialError(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
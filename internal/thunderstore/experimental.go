@@ -0,0 +1,213 @@
+package thunderstore
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+type Strategy string
+
+const (
+	StrategyPackageAPI Strategy = "experimental-package"
+	StrategyVersionAPI Strategy = "experimental-version"
+	StrategyBulkIndex  Strategy = "bulk-index"
+)
+
+type experimentalPackage struct {
+	Namespace         string              `json:"namespace"`
+	Name              string              `json:"name"`
+	FullName          string              `json:"full_name"`
+	Owner             string              `json:"owner"`
+	PackageURL        string              `json:"package_url"`
+	IsDeprecated      bool                `json:"is_deprecated"`
+	Latest            experimentalVersion `json:"latest"`
+	CommunityListings []struct {
+		Community      string   `json:"community"`
+		HasNSFWContent bool     `json:"has_nsfw_content"`
+		Categories     []string `json:"categories"`
+	} `json:"community_listings"`
+}
+
+type experimentalVersion struct {
+	Namespace     string   `json:"namespace"`
+	Name          string   `json:"name"`
+	VersionNumber string   `json:"version_number"`
+	FullName      string   `json:"full_name"`
+	Description   string   `json:"description"`
+	DownloadURL   string   `json:"download_url"`
+	Dependencies  []string `json:"dependencies"`
+}
+
+func (v experimentalVersion) packageVersion() PackageVersion {
+	return PackageVersion{
+		VersionNumber: v.VersionNumber,
+		DownloadURL:   v.DownloadURL,
+		Dependencies:  v.Dependencies,
+		FullName:      v.FullName,
+		Description:   v.Description,
+	}
+}
+
+func (c *Client) Package(community, fullName string) (*Package, Strategy, error) {
+	namespace, name, ok := strings.Cut(fullName, "-")
+	if !ok {
+		return nil, "", fmt.Errorf("invalid package name format: %s (expected namespace-name)", fullName)
+	}
+
+	if c.experimentalEnabled() {
+		pkg, err := c.experimentalPackage(community, namespace, name)
+		if err == nil {
+			c.mu.Lock()
+			c.experimentalServed = true
+			c.mu.Unlock()
+			return c.checkPackage(community, fullName, pkg, StrategyPackageAPI)
+		}
+		if errors.Is(err, ErrRateLimited) || errors.Is(err, ErrNotFound) && c.experimentalVerified() {
+			return nil, StrategyPackageAPI, err
+		}
+		logger().Warn("Per-package lookup failed, falling back to the community index", "package", fullName, "community", community, "error", err)
+
+		pkg, bulkErr := c.indexedPackage(community, namespace, name)
+		if bulkErr == nil && errors.Is(err, ErrNotFound) {
+			c.disableExperimental()
+		}
+		if bulkErr != nil {
+			return nil, StrategyBulkIndex, bulkErr
+		}
+		return c.checkPackage(community, fullName, pkg, StrategyBulkIndex)
+	}
+
+	pkg, err := c.indexedPackage(community, namespace, name)
+	if err != nil {
+		return nil, StrategyBulkIndex, err
+	}
+	return c.checkPackage(community, fullName, pkg, StrategyBulkIndex)
+}
+
+func (c *Client) PackageVersion(fullName, version string) (*PackageVersion, error) {
+	namespace, name, ok := strings.Cut(fullName, "-")
+	if !ok {
+		return nil, fmt.Errorf("invalid package name format: %s (expected namespace-name)", fullName)
+	}
+
+	data, err := c.fetch(http.MethodGet, fmt.Sprintf("%s/api/experimental/package/%s/%s/%s/", c.BaseURL,
+		url.PathEscape(namespace), url.PathEscape(name), url.PathEscape(version)), nil, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s version %s: %w", fullName, version, err)
+	}
+
+	var result experimentalVersion
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse version response for %s: %w", fullName, err)
+	}
+	if result.VersionNumber != version || result.DownloadURL == "" {
+		return nil, fmt.Errorf("unexpected version response for %s %s", fullName, version)
+	}
+
+	packageVersion := result.packageVersion()
+	return &packageVersion, nil
+}
+
+func (c *Client) experimentalPackage(community, namespace, name string) (*Package, error) {
+	data, err := c.fetch(http.MethodGet, fmt.Sprintf("%s/api/experimental/package/%s/%s/", c.BaseURL,
+		url.PathEscape(namespace), url.PathEscape(name)), nil, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var result experimentalPackage
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse package response for %s-%s: %w", namespace, name, err)
+	}
+	if result.Latest.VersionNumber == "" {
+		return nil, fmt.Errorf("package response for %s-%s has no latest version", namespace, name)
+	}
+
+	pkg := &Package{
+		FullName:     result.FullName,
+		Name:         result.Name,
+		Owner:        result.Owner,
+		Versions:     []PackageVersion{result.Latest.packageVersion()},
+		PackageURL:   result.PackageURL,
+		IsDeprecated: result.IsDeprecated,
+	}
+	if pkg.Owner == "" {
+		pkg.Owner = result.Namespace
+	}
+	pkg.Latest = &pkg.Versions[0]
+
+	listed := len(result.CommunityListings) == 0
+	for _, listing := range result.CommunityListings {
+		if listing.Community == community {
+			listed = true
+			pkg.HasNSFWContent = listing.HasNSFWContent
+			pkg.Categories = listing.Categories
+		}
+	}
+	if !listed {
+		return nil, &PackageError{Community: community, FullName: namespace + "-" + name, Err: ErrNotFound}
+	}
+	return pkg, nil
+}
+
+func (c *Client) indexedPackage(community, namespace, name string) (*Package, error) {
+	packages, err := c.Packages(community)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range packages {
+		if packages[i].Owner == namespace && packages[i].Name == name {
+			pkg := packages[i]
+			return &pkg, nil
+		}
+	}
+	return nil, &PackageError{Community: community, FullName: namespace + "-" + name, Err: ErrNotFound}
+}
+
+func (c *Client) checkPackage(community, fullName string, pkg *Package, strategy Strategy) (*Package, Strategy, error) {
+	if pkg.Latest == nil {
+		return nil, strategy, &PackageError{Community: community, FullName: fullName, Err: ErrNotFound}
+	}
+	if pkg.IsDeprecated {
+		if c.RejectDeprecated {
+			return nil, strategy, &PackageError{Community: community, FullName: fullName, Err: ErrDeprecated}
+		}
+		logger().Warn("Package is deprecated", "package", fullName)
+	}
+	return pkg, strategy, nil
+}
+
+func (c *Client) experimentalEnabled() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return !c.DisableExperimental
+}
+
+func (c *Client) experimentalVerified() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.experimentalServed
+}
+
+func (c *Client) disableExperimental() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.DisableExperimental {
+		logger().Info("Thunderstore does not serve per-package endpoints, using the bulk index", "url", c.BaseURL)
+	}
+	c.DisableExperimental = true
+}
+
+func (p *Package) Version(version string) *PackageVersion {
+	for i := range p.Versions {
+		if p.Versions[i].VersionNumber == version {
+			return &p.Versions[i]
+		}
+	}
+	return nil
+}
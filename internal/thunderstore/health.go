@@ -0,0 +1,41 @@
+package thunderstore
+
+import "strings"
+
+type HealthFlag string
+
+const (
+	HealthDeprecated     HealthFlag = "deprecated"
+	HealthVersionRemoved HealthFlag = "version-removed"
+	HealthNSFW           HealthFlag = "nsfw"
+	HealthMissing        HealthFlag = "missing"
+)
+
+func (p *Package) Health() []HealthFlag {
+	var flags []HealthFlag
+	if p.IsDeprecated {
+		flags = append(flags, HealthDeprecated)
+	}
+	if p.HasNSFWContent || hasNSFWCategory(p.Categories) {
+		flags = append(flags, HealthNSFW)
+	}
+	return flags
+}
+
+func HasFlag(flags []HealthFlag, flag HealthFlag) bool {
+	for _, f := range flags {
+		if f == flag {
+			return true
+		}
+	}
+	return false
+}
+
+func hasNSFWCategory(categories []string) bool {
+	for _, category := range categories {
+		if strings.EqualFold(strings.TrimSpace(category), "nsfw") {
+			return true
+		}
+	}
+	return false
+}
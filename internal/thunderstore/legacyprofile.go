@@ -0,0 +1,41 @@
+package thunderstore
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+func (c *Client) CreateLegacyProfile(payload []byte) (string, error) {
+	data, err := c.fetch(http.MethodPost, c.BaseURL+"/api/experimental/legacyprofile/create/", payload, "application/octet-stream")
+	if err != nil {
+		return "", fmt.Errorf("failed to upload profile: %w", err)
+	}
+
+	var result struct {
+		Key string `json:"key"`
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return "", fmt.Errorf("failed to parse profile upload response: %w", err)
+	}
+	if result.Key == "" {
+		return "", fmt.Errorf("profile upload response did not contain a code")
+	}
+	return result.Key, nil
+}
+
+func (c *Client) LegacyProfile(code string) ([]byte, error) {
+	getURL := fmt.Sprintf("%s/api/experimental/legacyprofile/get/%s/", c.BaseURL, url.PathEscape(code))
+	logger().Info("Fetching profile code", "code", code, "url", getURL)
+
+	data, err := c.fetch(http.MethodGet, getURL, nil, "")
+	if errors.Is(err, ErrNotFound) {
+		return nil, fmt.Errorf("profile code %s: %w", code, ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch profile code: %w", err)
+	}
+	return data, nil
+}
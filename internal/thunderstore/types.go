@@ -0,0 +1,22 @@
+package thunderstore
+
+type Package struct {
+	FullName       string           `json:"full_name"`
+	Name           string           `json:"name"`
+	Owner          string           `json:"owner"`
+	Versions       []PackageVersion `json:"versions"`
+	PackageURL     string           `json:"package_url"`
+	IsDeprecated   bool             `json:"is_deprecated"`
+	HasNSFWContent bool             `json:"has_nsfw_content"`
+	Categories     []string         `json:"categories"`
+	Latest         *PackageVersion  `json:"-"`
+}
+
+type PackageVersion struct {
+	VersionNumber string   `json:"version_number"`
+	DownloadURL   string   `json:"download_url"`
+	Dependencies  []string `json:"dependencies"`
+	FileSize      int64    `json:"file_size"`
+	FullName      string   `json:"full_name"`
+	Description   string   `json:"description"`
+}
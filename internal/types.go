@@ -1,16 +1,65 @@
 package internal
 
+import "strings"
+
 const (
 	WindowWidth  = 600
 	WindowHeight = 400
 	AppName      = "Wesleys Profiles"
 	AppVersion   = "v1.3.0"
 	AppID        = "fyi.wesley.modhelper"
+
+	ManifestSchemaVersion = 2
 )
 
 type Config struct {
-	ManifestURL string `json:"manifest_url"`
-	TargetDir   string `json:"target_dir"`
+	Version int `json:"version"`
+
+	ManifestURL     string `json:"manifest_url"`
+	TargetDir       string `json:"target_dir"`
+	ThunderstoreURL string `json:"thunderstore_url,omitempty"`
+	UpdateChannel   string `json:"update_channel,omitempty"`
+	UpdateFeedURL   string `json:"update_feed_url,omitempty"`
+
+	DownloadLimitKBps int    `json:"download_limit_kbps,omitempty"`
+	Language          string `json:"language,omitempty"`
+
+	HTTPTimeoutSeconds     int    `json:"http_timeout_seconds,omitempty"`
+	RetryAttempts          int    `json:"retry_attempts,omitempty"`
+	ManifestRefreshSeconds int    `json:"manifest_refresh_seconds,omitempty"`
+	UpdateCheckHours       int    `json:"update_check_hours,omitempty"`
+	LaunchMode             string `json:"launch_mode,omitempty"`
+
+	GameLaunch map[string]LaunchOptions `json:"game_launch,omitempty"`
+}
+
+const (
+	LaunchSteamURL  = "steam-url"
+	LaunchAppLaunch = "applaunch"
+	LaunchDirect    = "direct"
+	LaunchCommand   = "command"
+)
+
+var LaunchStrategies = []string{LaunchSteamURL, LaunchAppLaunch, LaunchDirect, LaunchCommand}
+
+type LaunchOptions struct {
+	Strategy   string            `json:"strategy,omitempty"`
+	Executable string            `json:"executable,omitempty"`
+	Command    string            `json:"command,omitempty"`
+	Env        map[string]string `json:"env,omitempty"`
+}
+
+func (o LaunchOptions) IsZero() bool {
+	return o.Strategy == "" && o.Executable == "" && o.Command == "" && len(o.Env) == 0
+}
+
+func ValidEnvName(name string) bool {
+	return name != "" && !strings.ContainsAny(name, "= \t\r\n\x00")
+}
+
+type Manifest struct {
+	SchemaVersion int    `json:"schemaVersion"`
+	Games         []Game `json:"games"`
 }
 
 type Game struct {
@@ -22,5 +71,62 @@ type Game struct {
 	LaunchArgs      string   `json:"launchArgs"`
 	Community       string   `json:"community"`
 	ExecutableNames []string `json:"executableNames"`
+	GameFolder      string   `json:"gameFolder,omitempty"`
 	Version         string   `json:"version"`
+	SHA256          string   `json:"sha256,omitempty"`
+	MinVersion      string   `json:"minVersion,omitempty"`
+	Changelog       string   `json:"changelog,omitempty"`
+
+	FailOnDeprecated bool `json:"failOnDeprecated,omitempty"`
+
+	Launch LaunchOptions `json:"launch,omitempty"`
+
+	Variants []ProfileVariant `json:"variants,omitempty"`
+	Variant  string           `json:"-"`
+}
+
+type ProfileVariant struct {
+	Name        string `json:"name"`
+	ProfileName string `json:"profileName"`
+	URL         string `json:"url"`
+	Version     string `json:"version"`
+	SHA256      string `json:"sha256,omitempty"`
+	MinVersion  string `json:"minVersion,omitempty"`
+	Changelog   string `json:"changelog,omitempty"`
+	Description string `json:"description"`
+	LaunchArgs  string `json:"launchArgs"`
+}
+
+func (g Game) ProfileVariants() []ProfileVariant {
+	if len(g.Variants) > 0 {
+		return g.Variants
+	}
+	return []ProfileVariant{{
+		Name:        g.Variant,
+		ProfileName: g.ProfileName,
+		URL:         g.URL,
+		Version:     g.Version,
+		SHA256:      g.SHA256,
+		MinVersion:  g.MinVersion,
+		Changelog:   g.Changelog,
+		LaunchArgs:  g.LaunchArgs,
+	}}
+}
+
+func (g Game) WithVariant(v ProfileVariant) Game {
+	g.Variant = v.Name
+	g.ProfileName = v.ProfileName
+	if g.ProfileName == "" {
+		g.ProfileName = v.Name
+	}
+	g.URL = v.URL
+	g.Version = v.Version
+	g.SHA256 = v.SHA256
+	g.MinVersion = v.MinVersion
+	g.Changelog = v.Changelog
+	if v.LaunchArgs != "" {
+		g.LaunchArgs = v.LaunchArgs
+	}
+	g.Variants = nil
+	return g
 }
@@ -0,0 +1,253 @@
+package updater
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	partialSuffix   = ".part"
+	validatorSuffix = ".validator"
+
+	downloadAttempts = 3
+	stallTimeout     = 60 * time.Second
+	partialMaxAge    = 7 * 24 * time.Hour
+)
+
+var errStalled = errors.New("download stalled")
+
+type DownloadOptions struct {
+	BytesPerSecond int64
+	Progress       func(downloaded, total int64)
+}
+
+func DownloadUpdate(ctx context.Context, downloadURL string, opts DownloadOptions) (string, error) {
+	logger().Info("Downloading update", "url", downloadURL)
+
+	partialPath := partialDownloadPath(downloadURL)
+
+	var lastErr error
+	for attempt := 1; attempt <= downloadAttempts; attempt++ {
+		err := downloadAttempt(ctx, downloadURL, partialPath, opts)
+		if err == nil {
+			finalPath := strings.TrimSuffix(partialPath, partialSuffix)
+			if err := os.Rename(partialPath, finalPath); err != nil {
+				return "", fmt.Errorf("failed to finish download: %w", err)
+			}
+			os.Remove(partialPath + validatorSuffix)
+			logger().Info("Download completed", "path", finalPath)
+			return finalPath, nil
+		}
+
+		if ctx.Err() != nil {
+			logger().Info("Update download cancelled, keeping partial file for resuming", "path", partialPath)
+			return "", ctx.Err()
+		}
+
+		lastErr = err
+		logger().Warn("Update download attempt failed", "attempt", attempt, "of", downloadAttempts, "error", err)
+
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-time.After(time.Duration(attempt) * 2 * time.Second):
+		}
+	}
+
+	return "", fmt.Errorf("failed to download update: %w", lastErr)
+}
+
+func downloadAttempt(ctx context.Context, downloadURL, partialPath string, opts DownloadOptions) error {
+	var offset int64
+	var validator string
+	if info, err := os.Stat(partialPath); err == nil {
+		if data, err := os.ReadFile(partialPath + validatorSuffix); err == nil && len(data) > 0 {
+			offset = info.Size()
+			validator = string(data)
+		} else {
+			logger().Info("Partial update download has no validator, restarting", "path", partialPath)
+		}
+	}
+
+	attemptCtx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	req, err := http.NewRequestWithContext(attemptCtx, http.MethodGet, downloadURL, nil)
+	if err != nil {
+		return err
+	}
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+		req.Header.Set("If-Range", validator)
+	}
+
+	client := &http.Client{
+		Transport: &http.Transport{
+			Proxy:                 http.ProxyFromEnvironment,
+			DialContext:           (&net.Dialer{Timeout: 30 * time.Second}).DialContext,
+			TLSHandshakeTimeout:   30 * time.Second,
+			ResponseHeaderTimeout: 30 * time.Second,
+		},
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	flags := os.O_CREATE | os.O_WRONLY
+	var total int64
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+		logger().Info("Resuming update download", "offset", offset)
+		flags |= os.O_APPEND
+		total = contentRangeTotal(resp.Header.Get("Content-Range"))
+	case http.StatusOK:
+		if offset > 0 {
+			logger().Info("Server sent the full file, restarting download")
+		}
+		offset = 0
+		flags |= os.O_TRUNC
+		total = resp.ContentLength
+		if err := saveValidator(partialPath, resp.Header); err != nil {
+			return err
+		}
+	case http.StatusRequestedRangeNotSatisfiable:
+		if offset > 0 && contentRangeTotal(resp.Header.Get("Content-Range")) == offset {
+			return nil
+		}
+		os.Remove(partialPath)
+		os.Remove(partialPath + validatorSuffix)
+		return fmt.Errorf("download failed with status %d", resp.StatusCode)
+	default:
+		return fmt.Errorf("download failed with status %d", resp.StatusCode)
+	}
+
+	file, err := os.OpenFile(partialPath, flags, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %w", err)
+	}
+	defer file.Close()
+
+	stall := time.AfterFunc(stallTimeout, func() {
+		cancel(errStalled)
+	})
+	defer stall.Stop()
+
+	reader := newThrottledReader(attemptCtx, resp.Body, opts.BytesPerSecond)
+	downloaded := offset
+
+	buffer := make([]byte, 32*1024)
+	for {
+		n, err := reader.Read(buffer)
+		if n > 0 {
+			stall.Reset(stallTimeout)
+			if _, writeErr := file.Write(buffer[:n]); writeErr != nil {
+				return fmt.Errorf("failed to write to temp file: %w", writeErr)
+			}
+			downloaded += int64(n)
+
+			if opts.Progress != nil {
+				opts.Progress(downloaded, total)
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			if cause := context.Cause(attemptCtx); errors.Is(cause, errStalled) {
+				return cause
+			}
+			return fmt.Errorf("failed to read download: %w", err)
+		}
+	}
+
+	if total > 0 && downloaded < total {
+		return fmt.Errorf("download ended early at %d of %d bytes", downloaded, total)
+	}
+	return nil
+}
+
+func partialDownloadPath(downloadURL string) string {
+	sum := sha256.Sum256([]byte(downloadURL))
+	return filepath.Join(os.TempDir(), "modhelper_update_"+hex.EncodeToString(sum[:6])+partialSuffix)
+}
+
+func saveValidator(partialPath string, header http.Header) error {
+	validator := header.Get("ETag")
+	if validator == "" || strings.HasPrefix(validator, "W/") {
+		validator = header.Get("Last-Modified")
+	}
+
+	validatorPath := partialPath + validatorSuffix
+	if validator == "" {
+		if err := os.Remove(validatorPath); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove download validator: %w", err)
+		}
+		return nil
+	}
+	if err := os.WriteFile(validatorPath, []byte(validator), 0644); err != nil {
+		return fmt.Errorf("failed to save download validator: %w", err)
+	}
+	return nil
+}
+
+func contentRangeTotal(header string) int64 {
+	i := strings.LastIndex(header, "/")
+	if i < 0 {
+		return -1
+	}
+	total, err := strconv.ParseInt(header[i+1:], 10, 64)
+	if err != nil {
+		return -1
+	}
+	return total
+}
+
+type throttledReader struct {
+	ctx            context.Context
+	reader         io.Reader
+	bytesPerSecond int64
+	start          time.Time
+	read           int64
+}
+
+func newThrottledReader(ctx context.Context, reader io.Reader, bytesPerSecond int64) io.Reader {
+	if bytesPerSecond <= 0 {
+		return reader
+	}
+	return &throttledReader{ctx: ctx, reader: reader, bytesPerSecond: bytesPerSecond, start: time.Now()}
+}
+
+func (t *throttledReader) Read(p []byte) (int, error) {
+	if int64(len(p)) > t.bytesPerSecond {
+		p = p[:t.bytesPerSecond]
+	}
+
+	n, err := t.reader.Read(p)
+	t.read += int64(n)
+
+	expected := time.Duration(float64(t.read) / float64(t.bytesPerSecond) * float64(time.Second))
+	if wait := expected - time.Since(t.start); wait > 0 {
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+		select {
+		case <-t.ctx.Done():
+			return n, t.ctx.Err()
+		case <-timer.C:
+		}
+	}
+	return n, err
+}
@@ -0,0 +1,132 @@
+package updater
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDownloadUpdate(t *testing.T) {
+	content := []byte(strings.Repeat("modhelper update ", 64))
+	half := len(content) / 2
+
+	tests := []struct {
+		name        string
+		partial     []byte
+		validator   string
+		handler     func(w http.ResponseWriter, r *http.Request)
+		want        []byte
+		wantRange   string
+		wantIfRange string
+	}{
+		{
+			name: "full download",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("ETag", `"v1"`)
+				http.ServeContent(w, r, "asset", time.Time{}, bytes.NewReader(content))
+			},
+			want: content,
+		},
+		{
+			name:      "resume with partial content",
+			partial:   content[:half],
+			validator: `"v1"`,
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("ETag", `"v1"`)
+				http.ServeContent(w, r, "asset", time.Time{}, bytes.NewReader(content))
+			},
+			want:        content,
+			wantRange:   "bytes=" + strconv.Itoa(half) + "-",
+			wantIfRange: `"v1"`,
+		},
+		{
+			name:      "asset replaced between attempts",
+			partial:   []byte(strings.Repeat("x", half)),
+			validator: `"v0"`,
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("ETag", `"v1"`)
+				http.ServeContent(w, r, "asset", time.Time{}, bytes.NewReader(content))
+			},
+			want:        content,
+			wantRange:   "bytes=" + strconv.Itoa(half) + "-",
+			wantIfRange: `"v0"`,
+		},
+		{
+			name:      "server ignores range",
+			partial:   []byte(strings.Repeat("x", half)),
+			validator: `"v1"`,
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("ETag", `"v1"`)
+				w.Write(content)
+			},
+			want:        content,
+			wantRange:   "bytes=" + strconv.Itoa(half) + "-",
+			wantIfRange: `"v1"`,
+		},
+		{
+			name:    "partial file without validator",
+			partial: []byte(strings.Repeat("x", half)),
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("ETag", `"v1"`)
+				http.ServeContent(w, r, "asset", time.Time{}, bytes.NewReader(content))
+			},
+			want: content,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tempDir := t.TempDir()
+			t.Setenv("TMPDIR", tempDir)
+			t.Setenv("TMP", tempDir)
+
+			var gotRange, gotIfRange string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotRange, gotIfRange = r.Header.Get("Range"), r.Header.Get("If-Range")
+				tt.handler(w, r)
+			}))
+			defer server.Close()
+
+			downloadURL := server.URL + "/ModHelper.exe"
+			partialPath := partialDownloadPath(downloadURL)
+			if tt.partial != nil {
+				if err := os.WriteFile(partialPath, tt.partial, 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if tt.validator != "" {
+				if err := os.WriteFile(partialPath+validatorSuffix, []byte(tt.validator), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			path, err := DownloadUpdate(context.Background(), downloadURL, DownloadOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got, tt.want) {
+				t.Errorf("downloaded %q, want %q", got, tt.want)
+			}
+			if gotRange != tt.wantRange || gotIfRange != tt.wantIfRange {
+				t.Errorf("Range = %q, If-Range = %q, want %q, %q", gotRange, gotIfRange, tt.wantRange, tt.wantIfRange)
+			}
+
+			for _, leftover := range []string{partialPath, partialPath + validatorSuffix} {
+				if _, err := os.Stat(leftover); !os.IsNotExist(err) {
+					t.Errorf("%s still exists after download", leftover)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,203 @@
+package updater
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/ur-wesley/modhelper/internal/semver"
+)
+
+type Channel string
+
+const (
+	ChannelStable Channel = "stable"
+	ChannelBeta   Channel = "beta"
+)
+
+type Feed struct {
+	Releases []FeedRelease `json:"releases"`
+}
+
+type FeedRelease struct {
+	Version string      `json:"version"`
+	Channel Channel     `json:"channel,omitempty"`
+	Notes   string      `json:"notes,omitempty"`
+	Assets  []FeedAsset `json:"assets"`
+}
+
+type FeedAsset struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+	Size int64  `json:"size,omitempty"`
+	OS   string `json:"os,omitempty"`
+	Arch string `json:"arch,omitempty"`
+}
+
+var nonExecutableExtensions = map[string]bool{
+	".txt": true, ".sig": true, ".json": true, ".md": true, ".sha256": true,
+	".zip": true, ".tar": true, ".gz": true, ".tgz": true,
+}
+
+func (c Channel) accepts(release FeedRelease) bool {
+	return release.Channel == ChannelStable || c == ChannelBeta
+}
+
+func ParseFeed(data []byte) ([]FeedRelease, error) {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 {
+		return nil, fmt.Errorf("empty feed")
+	}
+
+	if trimmed[0] == '[' {
+		var releases []GitHubRelease
+		if err := json.Unmarshal(trimmed, &releases); err != nil {
+			return nil, err
+		}
+		return fromGitHub(releases), nil
+	}
+
+	var probe struct {
+		TagName  *string          `json:"tag_name"`
+		Releases *json.RawMessage `json:"releases"`
+	}
+	if err := json.Unmarshal(trimmed, &probe); err != nil {
+		return nil, err
+	}
+
+	if probe.TagName != nil {
+		var release GitHubRelease
+		if err := json.Unmarshal(trimmed, &release); err != nil {
+			return nil, err
+		}
+		return fromGitHub([]GitHubRelease{release}), nil
+	}
+
+	if probe.Releases == nil {
+		return nil, fmt.Errorf("feed is neither a GitHub releases response nor has a \"releases\" list")
+	}
+
+	var feed Feed
+	if err := json.Unmarshal(trimmed, &feed); err != nil {
+		return nil, err
+	}
+
+	releases := make([]FeedRelease, 0, len(feed.Releases))
+	for _, release := range feed.Releases {
+		if release.Version == "" {
+			continue
+		}
+		release.Channel = Channel(strings.ToLower(string(release.Channel)))
+		if release.Channel == "" {
+			release.Channel = ChannelStable
+			if v, err := semver.Parse(release.Version); err == nil && len(v.Prerelease) > 0 {
+				release.Channel = ChannelBeta
+			}
+		}
+		releases = append(releases, release)
+	}
+	return releases, nil
+}
+
+func fromGitHub(releases []GitHubRelease) []FeedRelease {
+	var result []FeedRelease
+	for _, release := range releases {
+		if release.Draft {
+			continue
+		}
+
+		channel := ChannelStable
+		if release.Prerelease {
+			channel = ChannelBeta
+		}
+
+		converted := FeedRelease{
+			Version: release.TagName,
+			Channel: channel,
+			Notes:   release.Body,
+		}
+		for _, asset := range release.Assets {
+			converted.Assets = append(converted.Assets, FeedAsset{
+				Name: asset.Name,
+				URL:  asset.BrowserDownloadURL,
+				Size: asset.Size,
+			})
+		}
+		result = append(result, converted)
+	}
+	return result
+}
+
+func LatestRelease(releases []FeedRelease, channel Channel) *FeedRelease {
+	var latest *FeedRelease
+	for i := range releases {
+		release := &releases[i]
+		if !channel.accepts(*release) {
+			continue
+		}
+		if latest == nil {
+			if _, err := semver.Parse(release.Version); err == nil {
+				latest = release
+			}
+			continue
+		}
+		if c, err := semver.Compare(release.Version, latest.Version); err == nil && c > 0 {
+			latest = release
+		}
+	}
+	return latest
+}
+
+func SelectAsset(assets []FeedAsset, goos, goarch string) *FeedAsset {
+	var fallback *FeedAsset
+	for i := range assets {
+		asset := &assets[i]
+		if asset.URL == "" || nonExecutableExtensions[strings.ToLower(path.Ext(asset.Name))] {
+			continue
+		}
+
+		assetOS, assetArch := assetPlatform(*asset)
+		if assetOS != goos {
+			continue
+		}
+		if assetArch == goarch {
+			return asset
+		}
+		if assetArch == "" && fallback == nil {
+			fallback = asset
+		}
+	}
+	return fallback
+}
+
+func assetPlatform(asset FeedAsset) (goos, goarch string) {
+	if asset.OS != "" {
+		return strings.ToLower(asset.OS), strings.ToLower(asset.Arch)
+	}
+
+	name := strings.ToLower(asset.Name)
+	name = strings.NewReplacer("x86_64", "amd64", "x86-64", "amd64").Replace(name)
+
+	tokens := strings.FieldsFunc(name, func(r rune) bool {
+		return (r < 'a' || r > 'z') && (r < '0' || r > '9')
+	})
+	for _, token := range tokens {
+		switch token {
+		case "windows", "win", "win64", "win32", "exe":
+			goos = "windows"
+		case "linux":
+			goos = "linux"
+		case "darwin", "macos", "mac", "osx":
+			goos = "darwin"
+		case "amd64", "x64":
+			goarch = "amd64"
+		case "arm64", "aarch64":
+			goarch = "arm64"
+		case "386", "i386", "x86":
+			goarch = "386"
+		}
+	}
+	return goos, goarch
+}
@@ -0,0 +1,268 @@
+package updater
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/ur-wesley/modhelper/internal"
+	"github.com/ur-wesley/modhelper/internal/semver"
+)
+
+const (
+	SelfTestFlag = "--self-test"
+	UpdatedEnv   = "MODHELPER_UPDATED"
+
+	selfTestTimeout = 30 * time.Second
+	startupGrace    = 5 * time.Second
+)
+
+var ErrSelfTestFailed = errors.New("new version failed its self-test")
+
+type Update struct {
+	Executable string
+	Backup     string
+}
+
+func SelfTestOutput() string {
+	return fmt.Sprintf("%s %s", internal.AppName, internal.AppVersion)
+}
+
+func ApplyUpdate(info *UpdateInfo, newExecutablePath string) (*Update, error) {
+	defer os.Remove(newExecutablePath)
+
+	if err := VerifyUpdate(info, newExecutablePath); err != nil {
+		return nil, err
+	}
+
+	currentExe, err := currentExecutable()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current executable path: %w", err)
+	}
+	return installUpdate(newExecutablePath, currentExe, info.LatestVersion)
+}
+
+func installUpdate(newExecutablePath, currentExe, version string) (*Update, error) {
+	logger().Info("Applying update", "from", newExecutablePath, "to", currentExe)
+
+	stagedPath := stagedExecutablePath(currentExe)
+	if err := copyFile(newExecutablePath, stagedPath); err != nil {
+		os.Remove(stagedPath)
+		return nil, fmt.Errorf("failed to stage update: %w", err)
+	}
+	if err := os.Chmod(stagedPath, 0755); err != nil {
+		os.Remove(stagedPath)
+		return nil, fmt.Errorf("failed to stage update: %w", err)
+	}
+
+	if err := runSelfTest(stagedPath, version); err != nil {
+		os.Remove(stagedPath)
+		return nil, err
+	}
+
+	update := &Update{
+		Executable: currentExe,
+		Backup:     currentExe + BackupSuffix,
+	}
+	if err := update.replace(stagedPath); err != nil {
+		os.Remove(stagedPath)
+		return nil, err
+	}
+
+	if err := runSelfTest(update.Executable, version); err != nil {
+		if rollbackErr := update.Rollback(); rollbackErr != nil {
+			return nil, fmt.Errorf("%w (rollback failed: %v)", err, rollbackErr)
+		}
+		return nil, err
+	}
+
+	logger().Info("Update installed", "path", update.Executable, "backup", update.Backup)
+	return update, nil
+}
+
+func (u *Update) replace(stagedPath string) error {
+	os.Remove(u.Backup)
+
+	if runtime.GOOS == "windows" {
+		if err := os.Rename(u.Executable, u.Backup); err != nil {
+			return fmt.Errorf("failed to move current executable aside: %w", err)
+		}
+		if err := os.Rename(stagedPath, u.Executable); err != nil {
+			if restoreErr := os.Rename(u.Backup, u.Executable); restoreErr != nil {
+				logger().Error("Failed to restore executable", "path", u.Executable, "error", restoreErr)
+			}
+			return fmt.Errorf("failed to install update: %w", err)
+		}
+		return nil
+	}
+
+	if err := os.Link(u.Executable, u.Backup); err != nil {
+		if err := copyFile(u.Executable, u.Backup); err != nil {
+			return fmt.Errorf("failed to create backup: %w", err)
+		}
+		if err := os.Chmod(u.Backup, 0755); err != nil {
+			return fmt.Errorf("failed to create backup: %w", err)
+		}
+	}
+	if err := os.Rename(stagedPath, u.Executable); err != nil {
+		return fmt.Errorf("failed to install update: %w", err)
+	}
+	return nil
+}
+
+func (u *Update) Rollback() error {
+	logger().Warn("Rolling back update", "path", u.Executable)
+
+	if runtime.GOOS == "windows" {
+		if err := os.Remove(u.Executable); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return os.Rename(u.Backup, u.Executable)
+}
+
+func (u *Update) Restart() error {
+	cmd := exec.Command(u.Executable)
+	cmd.Dir = filepath.Dir(u.Executable)
+	cmd.Env = append(os.Environ(), UpdatedEnv+"=1")
+
+	if err := cmd.Start(); err != nil {
+		return u.failStart(err)
+	}
+
+	exited := make(chan error, 1)
+	go func() {
+		exited <- cmd.Wait()
+	}()
+
+	select {
+	case err := <-exited:
+		if err != nil {
+			return u.failStart(err)
+		}
+		logger().Warn("Updated application exited during startup")
+	case <-time.After(startupGrace):
+		logger().Info("Updated application started")
+	}
+	return nil
+}
+
+func (u *Update) failStart(err error) error {
+	if rollbackErr := u.Rollback(); rollbackErr != nil {
+		return fmt.Errorf("updated application failed to start: %w (rollback failed: %v)", err, rollbackErr)
+	}
+	return fmt.Errorf("updated application failed to start, previous version restored: %w", err)
+}
+
+func runSelfTest(path, expectedVersion string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), selfTestTimeout)
+	defer cancel()
+
+	var output bytes.Buffer
+	cmd := exec.CommandContext(ctx, path, SelfTestFlag)
+	cmd.Dir = filepath.Dir(path)
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+
+	if err := cmd.Run(); err != nil {
+		if detail := strings.TrimSpace(output.String()); detail != "" {
+			return fmt.Errorf("%w: %v: %s", ErrSelfTestFailed, err, detail)
+		}
+		return fmt.Errorf("%w: %v", ErrSelfTestFailed, err)
+	}
+
+	fields := strings.Fields(output.String())
+	if len(fields) == 0 {
+		return fmt.Errorf("%w: no output", ErrSelfTestFailed)
+	}
+	if expectedVersion != "" {
+		version := fields[len(fields)-1]
+		if c, err := semver.Compare(version, expectedVersion); err != nil || c != 0 {
+			return fmt.Errorf("%w: reports version %s, expected %s", ErrSelfTestFailed, version, expectedVersion)
+		}
+	}
+
+	logger().Info("Self-test passed", "path", path, "output", strings.TrimSpace(output.String()))
+	return nil
+}
+
+func currentExecutable() (string, error) {
+	path, err := os.Executable()
+	if err != nil {
+		return "", err
+	}
+	return filepath.EvalSymlinks(path)
+}
+
+func stagedExecutablePath(currentExe string) string {
+	ext := filepath.Ext(currentExe)
+	return strings.TrimSuffix(currentExe, ext) + StagedSuffix + ext
+}
+
+func copyFile(src, dst string) error {
+	sourceFile, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer sourceFile.Close()
+
+	destFile, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(destFile, sourceFile); err != nil {
+		destFile.Close()
+		return err
+	}
+	return destFile.Close()
+}
+
+func CleanupUpdateFiles() {
+	currentExe, err := currentExecutable()
+	if err != nil {
+		return
+	}
+	cleanupUpdateFiles(currentExe)
+}
+
+func cleanupUpdateFiles(currentExe string) {
+	dir := filepath.Dir(currentExe)
+
+	if os.Getenv(UpdatedEnv) == "" {
+		backupPath := currentExe + BackupSuffix
+		if _, err := os.Stat(backupPath); err == nil {
+			if err := os.Remove(backupPath); err == nil {
+				logger().Info("Cleaned up backup file", "path", backupPath)
+			}
+		}
+	}
+
+	os.Remove(stagedExecutablePath(currentExe))
+
+	files, err := filepath.Glob(filepath.Join(os.TempDir(), "modhelper_update_*"))
+	if err == nil {
+		for _, file := range files {
+			partial := strings.TrimSuffix(file, validatorSuffix)
+			if strings.HasSuffix(partial, partialSuffix) {
+				if info, err := os.Stat(partial); err == nil && time.Since(info.ModTime()) < partialMaxAge {
+					continue
+				}
+			}
+			os.Remove(file)
+		}
+	}
+
+	scriptPath := filepath.Join(dir, "update.bat")
+	if _, err := os.Stat(scriptPath); err == nil {
+		os.Remove(scriptPath)
+	}
+}
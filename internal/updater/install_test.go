@@ -0,0 +1,174 @@
+package updater
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func selfTestScript(version string) string {
+	return "#!/bin/sh\necho ModHelper " + version + "\n"
+}
+
+func writeExecutable(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0755); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestInstallUpdate(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("self-test executables are shell scripts")
+	}
+
+	current := selfTestScript("1.0.0")
+	tests := []struct {
+		name          string
+		newExecutable string
+		wantErr       error
+		wantInstalled bool
+	}{
+		{
+			name:          "replaces the executable",
+			newExecutable: selfTestScript("2.0.0"),
+			wantInstalled: true,
+		},
+		{
+			name:          "staged self-test fails",
+			newExecutable: "#!/bin/sh\necho broken >&2\nexit 1\n",
+			wantErr:       ErrSelfTestFailed,
+		},
+		{
+			name:          "staged self-test reports the wrong version",
+			newExecutable: selfTestScript("1.5.0"),
+			wantErr:       ErrSelfTestFailed,
+		},
+		{
+			name:          "installed self-test fails and rolls back",
+			newExecutable: "#!/bin/sh\ncase \"$0\" in\n*" + StagedSuffix + ") echo ModHelper 2.0.0 ;;\n*) exit 1 ;;\nesac\n",
+			wantErr:       ErrSelfTestFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			currentExe := filepath.Join(dir, "ModHelper")
+			newExe := filepath.Join(dir, "download")
+			writeExecutable(t, currentExe, current)
+			writeExecutable(t, newExe, tt.newExecutable)
+
+			update, err := installUpdate(newExe, currentExe, "2.0.0")
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("installUpdate() error = %v, want %v", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := os.Stat(stagedExecutablePath(currentExe)); !os.IsNotExist(err) {
+				t.Error("staged executable left behind")
+			}
+
+			if !tt.wantInstalled {
+				if got := readFile(t, currentExe); got != current {
+					t.Errorf("current executable = %q, want the previous version restored", got)
+				}
+				if _, err := os.Stat(currentExe + BackupSuffix); !os.IsNotExist(err) {
+					t.Error("backup left behind after a failed update")
+				}
+				return
+			}
+
+			if got := readFile(t, update.Executable); got != tt.newExecutable {
+				t.Errorf("installed executable = %q, want %q", got, tt.newExecutable)
+			}
+			if got := readFile(t, update.Backup); got != current {
+				t.Errorf("backup = %q, want %q", got, current)
+			}
+
+			if err := update.Rollback(); err != nil {
+				t.Fatal(err)
+			}
+			if got := readFile(t, currentExe); got != current {
+				t.Errorf("executable after rollback = %q, want %q", got, current)
+			}
+		})
+	}
+}
+
+func TestCleanupUpdateFiles(t *testing.T) {
+	tests := []struct {
+		name       string
+		updated    bool
+		wantBackup bool
+	}{
+		{name: "after a normal start", wantBackup: false},
+		{name: "right after an update", updated: true, wantBackup: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			tempDir := t.TempDir()
+			t.Setenv("TMPDIR", tempDir)
+			t.Setenv("TMP", tempDir)
+			if tt.updated {
+				t.Setenv(UpdatedEnv, "1")
+			} else {
+				t.Setenv(UpdatedEnv, "")
+			}
+
+			currentExe := filepath.Join(dir, "ModHelper.exe")
+			writeExecutable(t, currentExe, "current")
+			writeExecutable(t, currentExe+BackupSuffix, "previous")
+			writeExecutable(t, stagedExecutablePath(currentExe), "staged")
+			writeExecutable(t, filepath.Join(dir, "update.bat"), "@echo off")
+
+			freshPartial := filepath.Join(tempDir, "modhelper_update_fresh"+partialSuffix)
+			stalePartial := filepath.Join(tempDir, "modhelper_update_stale"+partialSuffix)
+			finished := filepath.Join(tempDir, "modhelper_update_done")
+			for _, path := range []string{freshPartial, freshPartial + validatorSuffix, stalePartial, stalePartial + validatorSuffix, finished} {
+				writeExecutable(t, path, "data")
+			}
+			old := time.Now().Add(-partialMaxAge - time.Hour)
+			if err := os.Chtimes(stalePartial, old, old); err != nil {
+				t.Fatal(err)
+			}
+
+			cleanupUpdateFiles(currentExe)
+
+			want := map[string]bool{
+				currentExe:                       true,
+				currentExe + BackupSuffix:        tt.wantBackup,
+				stagedExecutablePath(currentExe): false,
+				filepath.Join(dir, "update.bat"): false,
+				freshPartial:                     true,
+				freshPartial + validatorSuffix:   true,
+				stalePartial:                     false,
+				stalePartial + validatorSuffix:   false,
+				finished:                         false,
+			}
+			for path, exists := range want {
+				_, err := os.Stat(path)
+				if got := err == nil; got != exists {
+					t.Errorf("%s exists = %v, want %v", filepath.Base(path), got, exists)
+				}
+			}
+		})
+	}
+}
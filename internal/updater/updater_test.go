@@ -0,0 +1,164 @@
+package updater
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+type testRelease struct {
+	server     *httptest.Server
+	asset      string
+	executable []byte
+	checksums  []byte
+	signature  []byte
+}
+
+func newTestRelease(t *testing.T) *testRelease {
+	t.Helper()
+
+	release := &testRelease{
+		asset:      fmt.Sprintf("ModHelper-%s-%s", runtime.GOOS, runtime.GOARCH),
+		executable: []byte("new executable"),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/owner/modhelper/releases", func(w http.ResponseWriter, r *http.Request) {
+		assets := func(tag string) []GitHubAsset {
+			base := release.server.URL + "/download/" + tag + "/"
+			return []GitHubAsset{
+				{Name: release.asset, BrowserDownloadURL: base + release.asset, Size: int64(len(release.executable))},
+				{Name: ChecksumsAsset, BrowserDownloadURL: base + ChecksumsAsset},
+				{Name: SignatureAsset, BrowserDownloadURL: base + SignatureAsset},
+			}
+		}
+		json.NewEncoder(w).Encode([]GitHubRelease{
+			{TagName: "v99.1.0", Draft: true, Assets: assets("v99.1.0")},
+			{TagName: "v99.0.0", Body: "Stable notes", Assets: assets("v99.0.0")},
+			{TagName: "v100.0.0-beta.1", Prerelease: true, Body: "Beta notes", Assets: assets("v100.0.0-beta.1")},
+		})
+	})
+	mux.HandleFunc("/download/", func(w http.ResponseWriter, r *http.Request) {
+		switch filepath.Base(r.URL.Path) {
+		case ChecksumsAsset:
+			w.Write(release.checksums)
+		case SignatureAsset:
+			w.Write(release.signature)
+		case release.asset:
+			w.Write(release.executable)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	release.server = httptest.NewServer(mux)
+	t.Cleanup(release.server.Close)
+	return release
+}
+
+func (r *testRelease) feedURL() string {
+	return r.server.URL + "/repos/owner/modhelper/releases"
+}
+
+func TestCheckFeedGitHubReleases(t *testing.T) {
+	release := newTestRelease(t)
+
+	tests := []struct {
+		channel Channel
+		version string
+		notes   string
+	}{
+		{ChannelStable, "v99.0.0", "Stable notes"},
+		{ChannelBeta, "v100.0.0-beta.1", "Beta notes"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.channel), func(t *testing.T) {
+			info, err := checkFeed(release.feedURL(), tt.channel)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !info.Available || info.LatestVersion != tt.version || info.ReleaseNotes != tt.notes {
+				t.Errorf("got available=%v version=%q notes=%q, want %q %q", info.Available, info.LatestVersion, info.ReleaseNotes, tt.version, tt.notes)
+			}
+
+			base := release.server.URL + "/download/" + tt.version + "/"
+			if info.AssetName != release.asset || info.DownloadURL != base+release.asset {
+				t.Errorf("asset = %q from %q", info.AssetName, info.DownloadURL)
+			}
+			if info.ChecksumsURL != base+ChecksumsAsset || info.SignatureURL != base+SignatureAsset {
+				t.Errorf("checksums = %q, signature = %q", info.ChecksumsURL, info.SignatureURL)
+			}
+		})
+	}
+}
+
+func TestVerifyUpdate(t *testing.T) {
+	release := newTestRelease(t)
+
+	publicKey, privateKey, err := GenerateSigningKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKey, _, err := GenerateSigningKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	executable := filepath.Join(dir, release.asset)
+	if err := os.WriteFile(executable, release.executable, 0755); err != nil {
+		t.Fatal(err)
+	}
+	tampered := filepath.Join(dir, "tampered")
+	if err := os.WriteFile(tampered, []byte("tampered executable"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	release.checksums, err = FormatChecksums([]string{executable})
+	if err != nil {
+		t.Fatal(err)
+	}
+	release.signature, err = SignChecksums(privateKey, release.checksums)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := checkFeed(release.feedURL(), ChannelStable)
+	if err != nil {
+		t.Fatal(err)
+	}
+	unsigned := *info
+	unsigned.SignatureURL = ""
+
+	tests := []struct {
+		name string
+		key  string
+		info *UpdateInfo
+		path string
+		want error
+	}{
+		{name: "valid", key: publicKey, info: info, path: executable},
+		{name: "no embedded key", key: "", info: info, path: executable, want: ErrSigningKeyMissing},
+		{name: "unsigned release", key: publicKey, info: &unsigned, path: executable, want: ErrSignatureMissing},
+		{name: "wrong key", key: otherKey, info: info, path: executable, want: ErrSignatureInvalid},
+		{name: "tampered download", key: publicKey, info: info, path: tampered, want: ErrChecksumMismatch},
+	}
+
+	defer func(key string) { SigningPublicKey = key }(SigningPublicKey)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SigningPublicKey = tt.key
+			err := VerifyUpdate(tt.info, tt.path)
+			if tt.want == nil && err != nil || tt.want != nil && !errors.Is(err, tt.want) {
+				t.Errorf("VerifyUpdate() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,179 @@
+package updater
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	ChecksumsAsset = "checksums.txt"
+	SignatureAsset = "checksums.txt.sig"
+
+	maxChecksumsSize = 1 << 20
+)
+
+var SigningPublicKey = ""
+
+var (
+	ErrSigningKeyMissing = errors.New("this build has no update signing key")
+	ErrChecksumsMissing  = errors.New("release has no checksums file")
+	ErrChecksumMissing   = errors.New("checksums file has no entry for the update")
+	ErrChecksumMismatch  = errors.New("update checksum does not match")
+	ErrSignatureMissing  = errors.New("release checksums are not signed")
+	ErrSignatureInvalid  = errors.New("checksums signature is invalid")
+)
+
+func VerifyUpdate(info *UpdateInfo, path string) error {
+	if SigningPublicKey == "" {
+		return ErrSigningKeyMissing
+	}
+	if info == nil || info.ChecksumsURL == "" {
+		return ErrChecksumsMissing
+	}
+	if info.SignatureURL == "" {
+		return ErrSignatureMissing
+	}
+
+	checksums, err := fetchReleaseFile(info.ChecksumsURL)
+	if err != nil {
+		return fmt.Errorf("failed to download checksums: %w", err)
+	}
+
+	signature, err := fetchReleaseFile(info.SignatureURL)
+	if err != nil {
+		return fmt.Errorf("failed to download checksums signature: %w", err)
+	}
+	if err := VerifySignature(SigningPublicKey, checksums, signature); err != nil {
+		return err
+	}
+	logger().Info("Checksums signature verified")
+
+	expected, ok := ParseChecksums(checksums)[info.AssetName]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrChecksumMissing, info.AssetName)
+	}
+
+	actual, err := fileSHA256(path)
+	if err != nil {
+		return fmt.Errorf("failed to hash update: %w", err)
+	}
+	if !strings.EqualFold(expected, actual) {
+		return fmt.Errorf("%w: expected %s, got %s", ErrChecksumMismatch, expected, actual)
+	}
+
+	logger().Info("Update checksum verified", "sha256", actual)
+	return nil
+}
+
+func ParseChecksums(data []byte) map[string]string {
+	checksums := make(map[string]string)
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			continue
+		}
+		checksums[strings.TrimPrefix(fields[1], "*")] = strings.ToLower(fields[0])
+	}
+	return checksums
+}
+
+func FormatChecksums(paths []string) ([]byte, error) {
+	var buf bytes.Buffer
+	for _, path := range paths {
+		sum, err := fileSHA256(path)
+		if err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(&buf, "%s  %s\n", sum, filepath.Base(path))
+	}
+	return buf.Bytes(), nil
+}
+
+func GenerateSigningKey() (publicKey, privateKey string, err error) {
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return "", "", err
+	}
+	return base64.StdEncoding.EncodeToString(public), base64.StdEncoding.EncodeToString(private.Seed()), nil
+}
+
+func SignChecksums(privateKey string, data []byte) ([]byte, error) {
+	seed, err := base64.StdEncoding.DecodeString(strings.TrimSpace(privateKey))
+	if err != nil || len(seed) != ed25519.SeedSize {
+		return nil, fmt.Errorf("invalid signing key")
+	}
+
+	signature := ed25519.Sign(ed25519.NewKeyFromSeed(seed), data)
+	return []byte(base64.StdEncoding.EncodeToString(signature) + "\n"), nil
+}
+
+func VerifySignature(publicKey string, data, signature []byte) error {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(publicKey))
+	if err != nil || len(key) != ed25519.PublicKeySize {
+		return fmt.Errorf("invalid signing public key")
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(signature)))
+	if err != nil || len(sig) != ed25519.SignatureSize {
+		return ErrSignatureInvalid
+	}
+
+	if !ed25519.Verify(ed25519.PublicKey(key), data, sig) {
+		return ErrSignatureInvalid
+	}
+	return nil
+}
+
+func fetchReleaseFile(url string) ([]byte, error) {
+	client := &http.Client{
+		Timeout: 30 * time.Second,
+	}
+
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("download failed with status %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxChecksumsSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxChecksumsSize {
+		return nil, fmt.Errorf("%s is too large", url)
+	}
+	return data, nil
+}
+
+func fileSHA256(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
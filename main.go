@@ -4,71 +4,61 @@ import (
 	"flag"
 	"fmt"
 	"io"
-	"log"
+	"log/slog"
 	"os"
 	"path/filepath"
-	"time"
+	"runtime"
 
 	"github.com/ur-wesley/modhelper/internal/config"
+	"github.com/ur-wesley/modhelper/internal/logging"
 	"github.com/ur-wesley/modhelper/internal/updater"
 	"github.com/ur-wesley/modhelper/ui"
 )
 
 func main() {
-	setupFileLogging()
+	adminMode := flag.Bool("admin", false, "Run in admin mode for configuration")
+	selfTest := flag.Bool("self-test", false, "Check that the executable starts and print its version")
+	flag.Parse()
+
+	if *selfTest {
+		fmt.Println(updater.SelfTestOutput())
+		return
+	}
+
+	logFile := setupLogging()
+	defer logFile.Close()
 
 	updater.CleanupUpdateFiles()
 
-	adminMode := flag.Bool("admin", false, "Run in admin mode for configuration")
-	flag.Parse()
+	if flag.NArg() > 0 {
+		code := runCommand(flag.Args())
+		logFile.Close()
+		os.Exit(code)
+	}
 
-	log.Printf("Starting %s %s", AppName, AppVersion)
+	slog.Info("Starting", "app", AppName, "version", AppVersion, "os", runtime.GOOS, "arch", runtime.GOARCH)
 
 	if *adminMode {
-		log.Println("Running in admin mode")
+		slog.Info("Running in admin mode")
 		ui.RunAdmin()
 	} else {
-		log.Println("Running in user mode")
+		slog.Info("Running in user mode")
 
 		cfg, err := config.Load()
 		if err != nil {
-			log.Printf("Failed to load config: %v", err)
-			return
+			slog.Warn("Config has problems, run with -admin to fix them", "error", err)
 		}
 
 		ui.ShowUserInterface(cfg)
 	}
 }
 
-func setupFileLogging() {
-	if isPackagedFyneApp() {
-		log.SetFlags(log.LstdFlags)
-		return
-	}
-
-	logsDir := "logs"
-	err := os.MkdirAll(logsDir, 0755)
-	if err != nil {
-		fmt.Printf("Warning: Could not create logs directory: %v\n", err)
-		return
-	}
-
-	timestamp := time.Now().Format("2006-01-02_15-04-05")
-	logFileName := fmt.Sprintf("modhelper_%s.log", timestamp)
-	logFilePath := filepath.Join(logsDir, logFileName)
-
-	logFile, err := os.OpenFile(logFilePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
+func setupLogging() io.Closer {
+	closer, err := logging.Setup(!isPackagedFyneApp())
 	if err != nil {
-		fmt.Printf("Warning: Could not create log file: %v\n", err)
-		return
+		slog.Warn("Could not open log file, logging to stderr", "error", err)
 	}
-
-	multiWriter := io.MultiWriter(os.Stdout, logFile)
-	log.SetOutput(multiWriter)
-
-	log.SetFlags(log.LstdFlags | log.Lshortfile)
-
-	fmt.Printf("Logging to file: %s\n", logFilePath)
+	return closer
 }
 
 func isPackagedFyneApp() bool {
@@ -1,7 +1,9 @@
 package ui
 
 import (
-	"log"
+	"errors"
+	"fmt"
+	"strings"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/app"
@@ -14,62 +16,73 @@ import (
 	"github.com/ur-wesley/modhelper/internal/config"
 )
 
+type settingField struct {
+	setting config.Setting
+	widget  fyne.CanvasObject
+	value   func() string
+}
+
 func RunAdmin() {
 	a := app.NewWithID("com.urwesley.modhelper.admin")
 
-	messages := internal.German()
-
-	w := a.NewWindow(internal.AppName + " - " + messages.AdminMode)
-	w.Resize(fyne.NewSize(600, 400))
-
-	cfg, err := config.Load()
-	if err != nil {
-		log.Printf("Failed to load config: %v", err)
-		cfg = &internal.Config{
-			ManifestURL: "https://gist.githubusercontent.com/ur-wesley/8e93a37dc70b7d8161e94fc62df061ee/raw",
-			TargetDir:   config.GetDefaultProfileDir(),
-		}
+	cfg, loadErr := config.Load()
+	if loadErr != nil {
+		logger().Error("Failed to load config", "error", loadErr)
 	}
 
-	manifestEntry := widget.NewEntry()
-	manifestEntry.SetText(cfg.ManifestURL)
-	manifestEntry.MultiLine = false
-
-	targetDirEntry := widget.NewEntry()
-	targetDirEntry.SetText(cfg.TargetDir)
-	targetDirEntry.MultiLine = false
+	messages := loadMessages(cfg)
 
-	form := &widget.Form{
-		Items: []*widget.FormItem{
-			{
-				Text:   messages.ManifestURL,
-				Widget: container.NewBorder(nil, nil, widget.NewIcon(theme.DocumentIcon()), nil, manifestEntry),
-			},
-			{
-				Text:   messages.TargetDir,
-				Widget: container.NewBorder(nil, nil, widget.NewIcon(theme.FolderIcon()), nil, targetDirEntry),
-			},
-		},
+	w := a.NewWindow(internal.AppName + " - " + messages.AdminMode)
+	w.Resize(fyne.NewSize(700, 640))
+
+	var fields []settingField
+	var sections []fyne.CanvasObject
+	for _, group := range config.SettingGroups {
+		form := widget.NewForm()
+		for _, setting := range config.Settings {
+			if setting.Group != group {
+				continue
+			}
+
+			field := newSettingField(setting, cfg, messages)
+			fields = append(fields, field)
+
+			label, help := settingText(setting.Key, messages)
+			form.AppendItem(&widget.FormItem{Text: label, Widget: field.widget, HintText: help})
+		}
+		sections = append(sections, widget.NewCard(settingGroupTitle(group, messages), "", form))
 	}
 
 	saveBtn := widget.NewButtonWithIcon(messages.Save, theme.DocumentSaveIcon(), func() {
-		newCfg := &internal.Config{
-			ManifestURL: manifestEntry.Text,
-			TargetDir:   targetDirEntry.Text,
+		newCfg := *cfg
+
+		var fieldErrs config.ValidationError
+		for _, field := range fields {
+			if err := field.setting.Set(&newCfg, field.value()); err != nil {
+				var fieldErr config.FieldError
+				if !errors.As(err, &fieldErr) {
+					fieldErr = config.FieldError{Field: field.setting.Key, Message: err.Error()}
+				}
+				fieldErrs = append(fieldErrs, fieldErr)
+			}
+		}
+		if len(fieldErrs) > 0 {
+			showConfigError(w, messages, fieldErrs)
+			return
 		}
 
-		err := config.Save(newCfg)
+		err := config.Save(&newCfg)
 		if err != nil {
-			log.Printf("Failed to save config: %v", err)
-			errorDialog := dialog.NewError(err, w)
-			errorDialog.Show()
+			logger().Error("Failed to save config", "error", err)
+			showConfigError(w, messages, err)
 			return
 		}
+		cfg = &newCfg
 
-		log.Println("Configuration saved successfully")
+		logger().Info("Configuration saved")
 		successDialog := dialog.NewInformation(
-			"✅ Konfiguration gespeichert",
-			"Die Einstellungen wurden erfolgreich gespeichert.",
+			messages.ConfigSavedTitle,
+			messages.ConfigSavedMessage,
 			w,
 		)
 		successDialog.Show()
@@ -86,12 +99,7 @@ func RunAdmin() {
 	)
 
 	infoIcon := widget.NewIcon(theme.InfoIcon())
-	infoText := widget.NewRichTextFromMarkdown(`**Konfiguration**
-
-• **Manifest-URL**: URL zum JSON-Manifest mit Spiellisten
-• **Zielordner**: Pfad für r2modman Profile Installation
-
-Änderungen werden sofort nach dem Speichern aktiv.`)
+	infoText := widget.NewLabel(messages.AdminInfo)
 	infoText.Wrapping = fyne.TextWrapWord
 
 	infoContainer := container.NewBorder(
@@ -100,14 +108,142 @@ func RunAdmin() {
 		infoText,
 	)
 
-	content := container.NewVBox(
-		container.NewPadded(infoContainer),
-		widget.NewSeparator(),
-		form,
-		widget.NewSeparator(),
-		container.NewCenter(buttons),
+	content := container.NewBorder(
+		container.NewVBox(container.NewPadded(infoContainer), widget.NewSeparator()),
+		container.NewVBox(widget.NewSeparator(), container.NewCenter(buttons)),
+		nil, nil,
+		container.NewVScroll(container.NewVBox(sections...)),
 	)
 
 	w.SetContent(container.NewPadded(content))
+	if loadErr != nil {
+		showConfigError(w, messages, loadErr)
+	}
 	w.ShowAndRun()
 }
+
+func newSettingField(setting config.Setting, cfg *internal.Config, messages internal.Messages) settingField {
+	current := setting.Get(cfg)
+
+	if setting.Kind == config.KindChoice {
+		values, labels := settingChoices(setting, messages)
+		choiceSelect := widget.NewSelect(labels, nil)
+
+		selected := setting.Default
+		for _, value := range values {
+			if strings.EqualFold(value, strings.TrimSpace(current)) {
+				selected = value
+			}
+		}
+		for i, value := range values {
+			if value == selected {
+				choiceSelect.SetSelectedIndex(i)
+			}
+		}
+
+		return settingField{
+			setting: setting,
+			widget:  container.NewBorder(nil, nil, widget.NewIcon(theme.SettingsIcon()), nil, choiceSelect),
+			value: func() string {
+				if i := choiceSelect.SelectedIndex(); i >= 0 {
+					return values[i]
+				}
+				return setting.Default
+			},
+		}
+	}
+
+	entry := widget.NewEntry()
+	entry.SetText(current)
+	entry.SetPlaceHolder(setting.Default)
+	if setting.Key == "target_dir" {
+		entry.SetPlaceHolder(config.GetDefaultProfileDir())
+	}
+
+	icon := theme.DocumentIcon()
+	switch setting.Kind {
+	case config.KindURL:
+		icon = theme.ComputerIcon()
+	case config.KindPath:
+		icon = theme.FolderIcon()
+	case config.KindNumber:
+		icon = theme.HistoryIcon()
+	}
+
+	return settingField{
+		setting: setting,
+		widget:  container.NewBorder(nil, nil, widget.NewIcon(icon), nil, entry),
+		value:   func() string { return entry.Text },
+	}
+}
+
+func settingChoices(setting config.Setting, messages internal.Messages) ([]string, []string) {
+	switch setting.Key {
+	case "language":
+		return append([]string{""}, setting.Choices...), languageOptions(messages)
+	case "update_channel":
+		return setting.Choices, []string{messages.UpdateChannelStable, messages.UpdateChannelBeta}
+	case "launch_mode":
+		return setting.Choices, []string{messages.LaunchModeSteam, messages.LaunchModeDirect}
+	}
+	return setting.Choices, setting.Choices
+}
+
+func settingGroupTitle(group string, messages internal.Messages) string {
+	switch group {
+	case config.GroupGeneral:
+		return messages.SettingsGeneral
+	case config.GroupNetwork:
+		return messages.SettingsNetwork
+	case config.GroupUpdates:
+		return messages.SettingsUpdates
+	case config.GroupLaunch:
+		return messages.SettingsLaunch
+	}
+	return group
+}
+
+func settingText(key string, messages internal.Messages) (string, string) {
+	switch key {
+	case "manifest_url":
+		return messages.ManifestURL, messages.HelpManifestURL
+	case "target_dir":
+		return messages.TargetDir, messages.HelpTargetDir
+	case "language":
+		return messages.Language, messages.HelpLanguage
+	case "manifest_refresh_seconds":
+		return messages.ManifestRefresh, messages.HelpManifestRefresh
+	case "thunderstore_url":
+		return messages.ThunderstoreURL, messages.HelpThunderstoreURL
+	case "http_timeout_seconds":
+		return messages.HTTPTimeout, messages.HelpHTTPTimeout
+	case "retry_attempts":
+		return messages.RetryAttempts, messages.HelpRetryAttempts
+	case "update_channel":
+		return messages.UpdateChannel, messages.HelpUpdateChannel
+	case "update_feed_url":
+		return messages.UpdateFeedURL, messages.HelpUpdateFeedURL
+	case "update_check_hours":
+		return messages.UpdateCheckInterval, messages.HelpUpdateCheckInterval
+	case "download_limit_kbps":
+		return messages.DownloadLimit, messages.HelpDownloadLimit
+	case "launch_mode":
+		return messages.LaunchMode, messages.HelpLaunchMode
+	}
+	return key + ":", ""
+}
+
+func showConfigError(parent fyne.Window, messages internal.Messages, err error) {
+	var validationErr config.ValidationError
+	if !errors.As(err, &validationErr) {
+		dialog.NewError(fmt.Errorf("%s: %v", messages.ConfigInvalid, err), parent).Show()
+		return
+	}
+
+	problems := make([]string, len(validationErr))
+	for i, fieldErr := range validationErr {
+		label, _ := settingText(fieldErr.Field, messages)
+		problems[i] = fmt.Sprintf("• %s %s", label, fieldErr.Message)
+	}
+	dialog.NewError(fmt.Errorf("%s:\n%s", messages.ConfigInvalid, strings.Join(problems, "\n")), parent).Show()
+}
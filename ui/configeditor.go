@@ -0,0 +1,296 @@
+package ui
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/ur-wesley/modhelper/internal"
+	"github.com/ur-wesley/modhelper/internal/profile"
+)
+
+func showConfigEditor(store *profile.ProfileStore, game internal.Game, messages internal.Messages, parent fyne.Window) {
+	files, err := store.ListProfileConfigs(game)
+	if err != nil {
+		logger().Error("Failed to list config files", "game", game.Name, "error", err)
+		dialog.ShowError(err, parent)
+		return
+	}
+	if len(files) == 0 {
+		dialog.ShowInformation(messages.ConfigEditorTitle, messages.NoConfigFiles, parent)
+		return
+	}
+
+	w := fyne.CurrentApp().NewWindow(fmt.Sprintf("%s - %s", messages.ConfigEditorTitle, game.Name))
+	w.Resize(fyne.NewSize(650, 550))
+
+	var currentPath string
+	var currentFile *profile.CfgFile
+	dirty := false
+
+	entriesContainer := container.NewVBox()
+
+	saveBtn := widget.NewButtonWithIcon(messages.Save, theme.DocumentSaveIcon(), nil)
+	saveBtn.Importance = widget.HighImportance
+	saveBtn.Disable()
+
+	setDirty := func(value bool) {
+		dirty = value
+		if dirty {
+			saveBtn.Enable()
+		} else {
+			saveBtn.Disable()
+		}
+	}
+
+	loadFile := func(relPath string) {
+		file, err := store.LoadProfileConfig(game, relPath)
+		if err != nil {
+			logger().Error("Failed to load config", "path", relPath, "game", game.Name, "error", err)
+			dialog.ShowError(err, w)
+			return
+		}
+
+		defaults, err := store.LoadProfileConfigDefaults(game, relPath)
+		if err != nil {
+			logger().Debug("No profile defaults", "path", relPath, "error", err)
+			defaults = nil
+		}
+
+		currentPath = relPath
+		currentFile = file
+
+		entriesContainer.RemoveAll()
+		for _, section := range file.Sections() {
+			sectionLabel := widget.NewLabel(section)
+			sectionLabel.TextStyle = fyne.TextStyle{Bold: true}
+			entriesContainer.Add(sectionLabel)
+
+			for _, entry := range file.Entries {
+				if entry.Section != section {
+					continue
+				}
+				entriesContainer.Add(createCfgEntryRow(file, entry, defaults, messages, func() { setDirty(true) }))
+			}
+			entriesContainer.Add(widget.NewSeparator())
+		}
+		entriesContainer.Refresh()
+		setDirty(false)
+	}
+
+	fileSelect := widget.NewSelect(files, nil)
+	fileSelect.OnChanged = func(relPath string) {
+		if relPath == currentPath {
+			return
+		}
+		if !dirty {
+			loadFile(relPath)
+			return
+		}
+
+		dialog.ShowConfirm(messages.ConfigEditorTitle, messages.DiscardConfigChanges, func(confirmed bool) {
+			if confirmed {
+				loadFile(relPath)
+				return
+			}
+			fileSelect.SetSelected(currentPath)
+		}, w)
+	}
+
+	saveBtn.OnTapped = func() {
+		if currentFile == nil {
+			return
+		}
+		if err := store.SaveProfileConfig(game, currentPath, currentFile); err != nil {
+			logger().Error("Failed to save config", "path", currentPath, "game", game.Name, "error", err)
+			dialog.ShowError(err, w)
+			return
+		}
+		setDirty(false)
+		dialog.ShowInformation(messages.ConfigEditorTitle, messages.ConfigSaved, w)
+	}
+
+	closeBtn := widget.NewButtonWithIcon(messages.Close, theme.CancelIcon(), func() {
+		w.Close()
+	})
+
+	content := container.NewBorder(
+		container.NewBorder(nil, nil, widget.NewLabel(messages.ConfigFile), nil, fileSelect),
+		container.NewCenter(container.NewHBox(saveBtn, closeBtn)),
+		nil, nil,
+		container.NewVScroll(entriesContainer),
+	)
+
+	w.SetContent(container.NewPadded(content))
+	fileSelect.SetSelected(files[0])
+	w.Show()
+}
+
+func createCfgEntryRow(file *profile.CfgFile, entry *profile.CfgEntry, defaults *profile.CfgFile, messages internal.Messages, onChanged func()) fyne.CanvasObject {
+	setValue := func(value string) {
+		if value == entry.Value {
+			return
+		}
+		if err := file.Set(entry.Section, entry.Key, value); err != nil {
+			logger().Warn("Failed to set config value", "section", entry.Section, "key", entry.Key, "error", err)
+			return
+		}
+		onChanged()
+	}
+
+	input, resetInput := createCfgEntryInput(entry, setValue)
+
+	resetBtn := widget.NewButtonWithIcon("", theme.ContentUndoIcon(), nil)
+	defaultValue, hasDefault := cfgEntryDefault(entry, defaults)
+	if hasDefault {
+		resetBtn.OnTapped = func() {
+			resetInput(defaultValue)
+			setValue(defaultValue)
+		}
+	} else {
+		resetBtn.Disable()
+	}
+
+	keyLabel := widget.NewLabel(entry.Key)
+	keyLabel.TextStyle = fyne.TextStyle{Bold: true}
+
+	row := container.NewVBox(keyLabel)
+	if entry.Description != "" {
+		descriptionLabel := widget.NewLabel(entry.Description)
+		descriptionLabel.Wrapping = fyne.TextWrapWord
+		descriptionLabel.Importance = widget.LowImportance
+		row.Add(descriptionLabel)
+	}
+	if hasDefault {
+		defaultLabel := widget.NewLabel(fmt.Sprintf("%s: %s", messages.ProfileDefault, defaultValue))
+		defaultLabel.Importance = widget.LowImportance
+		defaultLabel.Wrapping = fyne.TextWrapWord
+		row.Add(defaultLabel)
+	}
+	row.Add(container.NewBorder(nil, nil, nil, resetBtn, input))
+
+	return row
+}
+
+func cfgEntryDefault(entry *profile.CfgEntry, defaults *profile.CfgFile) (string, bool) {
+	if defaults != nil {
+		if defaultEntry := defaults.Entry(entry.Section, entry.Key); defaultEntry != nil {
+			return defaultEntry.Value, true
+		}
+	}
+	return entry.Default, entry.HasDefault
+}
+
+func createCfgEntryInput(entry *profile.CfgEntry, setValue func(string)) (fyne.CanvasObject, func(string)) {
+	switch entry.Kind() {
+	case profile.CfgBool:
+		check := widget.NewCheck("", nil)
+		check.SetChecked(strings.EqualFold(entry.Value, "true"))
+		check.OnChanged = func(checked bool) {
+			setValue(strconv.FormatBool(checked))
+		}
+		return check, func(value string) {
+			check.SetChecked(strings.EqualFold(value, "true"))
+		}
+
+	case profile.CfgEnum:
+		selectWidget := widget.NewSelect(entry.AcceptableValues, nil)
+		selectWidget.SetSelected(entry.Value)
+		selectWidget.OnChanged = setValue
+		return selectWidget, selectWidget.SetSelected
+
+	case profile.CfgFlags:
+		checkGroup := widget.NewCheckGroup(entry.AcceptableValues, nil)
+		checkGroup.Horizontal = true
+		checkGroup.SetSelected(splitCfgFlags(entry.Value))
+		checkGroup.OnChanged = func(selected []string) {
+			setValue(strings.Join(selected, ", "))
+		}
+		return checkGroup, func(value string) {
+			checkGroup.SetSelected(splitCfgFlags(value))
+		}
+
+	case profile.CfgInt, profile.CfgFloat:
+		if entry.HasRange {
+			return createCfgRangeInput(entry, setValue)
+		}
+	}
+
+	input := widget.NewEntry()
+	input.SetText(entry.Value)
+	switch entry.Kind() {
+	case profile.CfgInt:
+		input.Validator = func(value string) error {
+			_, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+			return err
+		}
+	case profile.CfgFloat:
+		input.Validator = func(value string) error {
+			_, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			return err
+		}
+	}
+	input.OnChanged = func(value string) {
+		if input.Validator != nil && input.Validator(value) != nil {
+			return
+		}
+		setValue(value)
+	}
+	return input, input.SetText
+}
+
+func createCfgRangeInput(entry *profile.CfgEntry, setValue func(string)) (fyne.CanvasObject, func(string)) {
+	isInt := entry.Kind() == profile.CfgInt
+
+	slider := widget.NewSlider(entry.Min, entry.Max)
+	if isInt {
+		slider.Step = 1
+	} else {
+		slider.Step = (entry.Max - entry.Min) / 100
+	}
+
+	format := func(value float64) string {
+		if isInt {
+			return strconv.FormatInt(int64(math.Round(value)), 10)
+		}
+		return strconv.FormatFloat(math.Round(value*10000)/10000, 'f', -1, 64)
+	}
+
+	parse := func(value string) float64 {
+		parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return entry.Min
+		}
+		return parsed
+	}
+
+	valueLabel := widget.NewLabel(entry.Value)
+	slider.SetValue(parse(entry.Value))
+	slider.OnChanged = func(value float64) {
+		formatted := format(value)
+		valueLabel.SetText(formatted)
+		setValue(formatted)
+	}
+
+	return container.NewBorder(nil, nil, nil, valueLabel, slider), func(value string) {
+		slider.SetValue(parse(value))
+		valueLabel.SetText(value)
+	}
+}
+
+func splitCfgFlags(value string) []string {
+	var flags []string
+	for _, flag := range strings.Split(value, ",") {
+		if flag = strings.TrimSpace(flag); flag != "" {
+			flags = append(flags, flag)
+		}
+	}
+	return flags
+}
@@ -0,0 +1,132 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/ur-wesley/modhelper/internal"
+	"github.com/ur-wesley/modhelper/internal/profile"
+	"github.com/ur-wesley/modhelper/internal/thunderstore"
+)
+
+func showHealthPanel(store *profile.ProfileStore, game internal.Game, messages internal.Messages) {
+	w := fyne.CurrentApp().NewWindow(fmt.Sprintf("%s - %s", messages.HealthTitle, game.Name))
+	w.Resize(fyne.NewSize(550, 450))
+
+	summaryLabel := widget.NewLabel(messages.CheckingHealth)
+	summaryLabel.Wrapping = fyne.TextWrapWord
+
+	progress := widget.NewProgressBarInfinite()
+	entriesContainer := container.NewVBox()
+
+	refreshBtn := widget.NewButtonWithIcon(messages.HealthRecheck, theme.ViewRefreshIcon(), nil)
+	closeBtn := widget.NewButtonWithIcon(messages.Close, theme.CancelIcon(), func() {
+		w.Close()
+	})
+
+	runCheck := func() {
+		refreshBtn.Disable()
+		progress.Show()
+		summaryLabel.SetText(messages.CheckingHealth)
+		entriesContainer.RemoveAll()
+
+		go func() {
+			results, err := store.CheckProfileHealth(game)
+
+			fyne.Do(func() {
+				progress.Hide()
+				refreshBtn.Enable()
+
+				if err != nil {
+					logger().Error("Health check failed", "game", game.Name, "error", err)
+					summaryLabel.SetText(fmt.Sprintf("%s: %v", messages.HealthCheckFailed, err))
+					return
+				}
+
+				issues := 0
+				for _, result := range results {
+					if len(result.Flags) == 0 && result.Error == nil {
+						continue
+					}
+					issues++
+					entriesContainer.Add(createHealthRow(result, messages))
+				}
+
+				if issues == 0 {
+					summaryLabel.SetText(messages.HealthOK.Format(len(results), len(results)))
+				} else {
+					summaryLabel.SetText(messages.HealthIssues.Format(issues, issues, len(results)))
+				}
+				entriesContainer.Refresh()
+			})
+		}()
+	}
+	refreshBtn.OnTapped = runCheck
+
+	content := container.NewBorder(
+		container.NewVBox(summaryLabel, progress),
+		container.NewCenter(container.NewHBox(refreshBtn, closeBtn)),
+		nil, nil,
+		container.NewVScroll(entriesContainer),
+	)
+
+	w.SetContent(container.NewPadded(content))
+	w.Show()
+	runCheck()
+}
+
+func createHealthRow(result profile.PackageHealth, messages internal.Messages) fyne.CanvasObject {
+	nameLabel := widget.NewLabel(fmt.Sprintf("%s %s", result.Name, result.Version))
+	nameLabel.TextStyle = fyne.TextStyle{Bold: true}
+
+	var details []string
+	for _, flag := range result.Flags {
+		details = append(details, healthFlagText(flag, messages))
+	}
+	if result.Error != nil {
+		details = append(details, result.Error.Error())
+	}
+
+	detailLabel := widget.NewLabel(strings.Join(details, ", "))
+	detailLabel.Wrapping = fyne.TextWrapWord
+	detailLabel.Importance = widget.WarningImportance
+
+	icon := widget.NewIcon(theme.WarningIcon())
+	if thunderstore.HasFlag(result.Flags, thunderstore.HealthMissing) || thunderstore.HasFlag(result.Flags, thunderstore.HealthVersionRemoved) {
+		icon.SetResource(theme.ErrorIcon())
+	}
+
+	return container.NewBorder(nil, nil, icon, nil, container.NewVBox(nameLabel, detailLabel))
+}
+
+func healthFlagText(flag thunderstore.HealthFlag, messages internal.Messages) string {
+	switch flag {
+	case thunderstore.HealthDeprecated:
+		return messages.HealthDeprecated
+	case thunderstore.HealthVersionRemoved:
+		return messages.HealthVersionRemoved
+	case thunderstore.HealthNSFW:
+		return messages.HealthNSFW
+	case thunderstore.HealthMissing:
+		return messages.HealthMissing
+	}
+	return string(flag)
+}
+
+func updateHealthButton(healthBtn *widget.Button, store *profile.ProfileStore, game internal.Game) {
+	healthBtn.SetIcon(theme.InfoIcon())
+	healthBtn.Importance = widget.MediumImportance
+
+	if report, err := store.LoadInstallReport(game); err == nil && (len(report.Flagged()) > 0 || len(report.Failed()) > 0) {
+		healthBtn.SetIcon(theme.WarningIcon())
+		healthBtn.Importance = widget.WarningImportance
+	}
+
+	healthBtn.Show()
+	healthBtn.Refresh()
+}
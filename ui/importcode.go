@@ -0,0 +1,71 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/ur-wesley/modhelper/internal"
+	"github.com/ur-wesley/modhelper/internal/profile"
+)
+
+func showImportCodeDialog(parent fyne.Window, messages internal.Messages, store *profile.ProfileStore, games []internal.Game, onImported func()) {
+	var importable []internal.Game
+	var gameNames []string
+	for _, game := range games {
+		if game.Community == "" || len(profile.ValidateGame(0, game)) > 0 {
+			continue
+		}
+		importable = append(importable, game)
+		gameNames = append(gameNames, game.Name)
+	}
+
+	gameSelect := widget.NewSelect(gameNames, nil)
+	gameSelect.PlaceHolder = messages.ImportGame
+
+	codeEntry := widget.NewEntry()
+	codeEntry.SetPlaceHolder("00000000-0000-0000-0000-000000000000")
+
+	profileNameEntry := widget.NewEntry()
+
+	items := []*widget.FormItem{
+		widget.NewFormItem(messages.ImportGame, gameSelect),
+		widget.NewFormItem(messages.ProfileCode, codeEntry),
+		widget.NewFormItem(messages.ImportProfileName, profileNameEntry),
+	}
+
+	importDialog := dialog.NewForm(messages.ImportCodeTitle, messages.Install, messages.Cancel, items, func(confirmed bool) {
+		index := gameSelect.SelectedIndex()
+		code := strings.TrimSpace(codeEntry.Text)
+		if !confirmed || index < 0 || code == "" {
+			return
+		}
+
+		game := importable[index]
+		game.ProfileName = strings.TrimSpace(profileNameEntry.Text)
+
+		progress := dialog.NewCustomWithoutButtons(messages.ImportCodeTitle, widget.NewLabel(messages.ImportingCode), parent)
+		progress.Show()
+
+		go func() {
+			profileName, err := store.ImportProfileCode(game, code)
+
+			fyne.Do(func() {
+				progress.Hide()
+				if err != nil {
+					logger().Error("Failed to import profile code", "code", code, "game", game.Name, "error", err)
+					dialog.ShowError(fmt.Errorf("%s: %v", messages.ImportFailed, err), parent)
+					return
+				}
+
+				dialog.ShowInformation(messages.ImportCodeTitle, fmt.Sprintf(messages.ImportSuccess, profileName), parent)
+				onImported()
+			})
+		}()
+	}, parent)
+	importDialog.Resize(fyne.NewSize(450, 250))
+	importDialog.Show()
+}
@@ -0,0 +1,26 @@
+package ui
+
+import (
+	"fyne.io/fyne/v2/lang"
+
+	"github.com/ur-wesley/modhelper/internal"
+)
+
+func loadMessages(cfg *internal.Config) internal.Messages {
+	configured := ""
+	if cfg != nil {
+		configured = cfg.Language
+	}
+
+	language := internal.ResolveLanguage(configured, lang.SystemLocale().LanguageString())
+	logger().Info("Using language", "language", language)
+	return internal.MessagesFor(language)
+}
+
+func languageOptions(messages internal.Messages) []string {
+	options := []string{messages.LanguageSystem}
+	for _, language := range internal.Languages {
+		options = append(options, language.Name)
+	}
+	return options
+}
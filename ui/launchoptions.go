@@ -0,0 +1,145 @@
+package ui
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/ur-wesley/modhelper/internal"
+	"github.com/ur-wesley/modhelper/internal/config"
+)
+
+func showLaunchOptions(cfg *internal.Config, game internal.Game, messages internal.Messages, parent fyne.Window, onSaved func()) {
+	base := *cfg
+	base.GameLaunch = nil
+	defaults := config.GetGameLaunch(&base, game)
+	override := cfg.GameLaunch[game.ID]
+
+	strategies := append([]string{""}, internal.LaunchStrategies...)
+	labels := make([]string, len(strategies))
+	for i, strategy := range strategies {
+		if strategy == "" {
+			labels[i] = fmt.Sprintf(messages.LaunchDefault, launchStrategyLabel(defaults.Strategy, messages))
+			continue
+		}
+		labels[i] = launchStrategyLabel(strategy, messages)
+	}
+
+	strategySelect := widget.NewSelect(labels, nil)
+	strategySelect.SetSelectedIndex(max(slices.Index(strategies, override.Strategy), 0))
+
+	executableEntry := widget.NewEntry()
+	executableEntry.SetText(override.Executable)
+	executableEntry.SetPlaceHolder(defaults.Executable)
+
+	commandEntry := widget.NewEntry()
+	commandEntry.SetText(override.Command)
+	commandEntry.SetPlaceHolder(defaults.Command)
+
+	envEntry := widget.NewMultiLineEntry()
+	envEntry.SetText(formatEnv(override.Env))
+	envEntry.SetPlaceHolder(formatEnv(game.Launch.Env))
+	envEntry.SetMinRowsVisible(3)
+
+	items := []*widget.FormItem{
+		widget.NewFormItem(messages.LaunchStrategy, strategySelect),
+		{Text: messages.LaunchExecutable, Widget: executableEntry, HintText: messages.HelpLaunchExecutable},
+		{Text: messages.LaunchCommand, Widget: commandEntry, HintText: messages.HelpLaunchCommand},
+		{Text: messages.LaunchEnv, Widget: envEntry, HintText: messages.HelpLaunchEnv},
+	}
+
+	title := fmt.Sprintf(messages.LaunchOptionsTitle, game.Name)
+	optionsDialog := dialog.NewForm(title, messages.Save, messages.Cancel, items, func(confirmed bool) {
+		if !confirmed {
+			return
+		}
+
+		env, err := parseEnv(envEntry.Text, messages)
+		if err != nil {
+			dialog.ShowError(err, parent)
+			return
+		}
+
+		options := internal.LaunchOptions{
+			Executable: strings.TrimSpace(executableEntry.Text),
+			Command:    strings.TrimSpace(commandEntry.Text),
+			Env:        env,
+		}
+		if index := strategySelect.SelectedIndex(); index > 0 {
+			options.Strategy = strategies[index]
+		}
+
+		previous := cfg.GameLaunch
+		gameLaunch := maps.Clone(previous)
+		if gameLaunch == nil {
+			gameLaunch = make(map[string]internal.LaunchOptions)
+		}
+		if options.IsZero() {
+			delete(gameLaunch, game.ID)
+		} else {
+			gameLaunch[game.ID] = options
+		}
+
+		cfg.GameLaunch = gameLaunch
+		if err := config.Save(cfg); err != nil {
+			cfg.GameLaunch = previous
+			logger().Error("Failed to save launch options", "game", game.Name, "error", err)
+			showConfigError(parent, messages, err)
+			return
+		}
+
+		logger().Info("Saved launch options", "game", game.Name, "strategy", options.Strategy)
+		onSaved()
+	}, parent)
+	optionsDialog.Resize(fyne.NewSize(520, 380))
+	optionsDialog.Show()
+}
+
+func launchStrategyLabel(strategy string, messages internal.Messages) string {
+	switch strategy {
+	case internal.LaunchSteamURL:
+		return messages.LaunchSteamURL
+	case internal.LaunchAppLaunch:
+		return messages.LaunchAppLaunch
+	case internal.LaunchDirect:
+		return messages.LaunchDirectExe
+	case internal.LaunchCommand:
+		return messages.LaunchCustomCommand
+	}
+	return strategy
+}
+
+func formatEnv(env map[string]string) string {
+	var lines []string
+	for _, key := range slices.Sorted(maps.Keys(env)) {
+		lines = append(lines, key+"="+env[key])
+	}
+	return strings.Join(lines, "\n")
+}
+
+func parseEnv(text string, messages internal.Messages) (map[string]string, error) {
+	var env map[string]string
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if !ok || !internal.ValidEnvName(key) {
+			return nil, fmt.Errorf(messages.InvalidEnvLine, line)
+		}
+
+		if env == nil {
+			env = make(map[string]string)
+		}
+		env[key] = value
+	}
+	return env, nil
+}
@@ -0,0 +1,51 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/ur-wesley/modhelper/internal"
+	"github.com/ur-wesley/modhelper/internal/profile"
+	"github.com/ur-wesley/modhelper/internal/support"
+)
+
+func showSupportBundleDialog(parent fyne.Window, cfg *internal.Config, store *profile.ProfileStore, messages internal.Messages) {
+	saveDialog := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, parent)
+			return
+		}
+		if writer == nil {
+			return
+		}
+
+		progress := dialog.NewCustomWithoutButtons(messages.SupportBundle, widget.NewLabel(messages.CreatingSupportBundle), parent)
+		progress.Show()
+
+		go func() {
+			err := support.WriteBundle(writer, cfg, store)
+			if closeErr := writer.Close(); err == nil {
+				err = closeErr
+			}
+
+			fyne.Do(func() {
+				progress.Hide()
+				if err != nil {
+					logger().Error("Failed to create support bundle", "error", err)
+					dialog.ShowError(fmt.Errorf("%s: %v", messages.SupportBundleFailed, err), parent)
+					return
+				}
+
+				logger().Info("Support bundle created", "path", writer.URI().Path())
+				dialog.ShowInformation(messages.SupportBundle, fmt.Sprintf(messages.SupportBundleCreated, writer.URI().Path()), parent)
+			})
+		}()
+	}, parent)
+
+	saveDialog.SetFileName(support.BundleName(time.Now()))
+	saveDialog.Show()
+}
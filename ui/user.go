@@ -1,9 +1,11 @@
 ﻿package ui
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io"
-	"log"
+	"log/slog"
 	"net/http"
 	"strings"
 	"time"
@@ -18,12 +20,18 @@ import (
 	"fyne.io/fyne/v2/widget"
 
 	"github.com/ur-wesley/modhelper/internal"
+	"github.com/ur-wesley/modhelper/internal/config"
+	"github.com/ur-wesley/modhelper/internal/logging"
 	"github.com/ur-wesley/modhelper/internal/profile"
 	"github.com/ur-wesley/modhelper/internal/r2modman"
 	"github.com/ur-wesley/modhelper/internal/steam"
 	"github.com/ur-wesley/modhelper/internal/updater"
 )
 
+func logger() *slog.Logger {
+	return logging.For("ui")
+}
+
 type GameListItem struct {
 	Game       internal.Game
 	Container  *fyne.Container
@@ -58,23 +66,29 @@ func fuzzyMatch(target, searchText string) bool {
 func ShowUserInterface(cfg *internal.Config) {
 	a := app.NewWithID("com.urwesley.modhelper")
 
-	messages := internal.German()
+	messages := loadMessages(cfg)
+
+	store := profile.NewProfileStore(cfg)
 
 	windowWidth, windowHeight := GetWindowDimensions()
 	w := a.NewWindow(fmt.Sprintf("%s %s", internal.AppName, internal.AppVersion))
 	w.Resize(fyne.NewSize(windowWidth, windowHeight))
 
 	infoButton := widget.NewButtonWithIcon("", theme.HelpIcon(), func() {
-		showInfoDialog(w, messages)
+		showInfoDialog(w, cfg, store, messages)
 	})
 	infoButton.Resize(fyne.NewSize(32, 32))
 
 	updateButton := widget.NewButtonWithIcon("", theme.DownloadIcon(), func() {
-		showUpdateDialog(w, messages)
+		showUpdateDialog(w, cfg, messages)
 	})
 	updateButton.Resize(fyne.NewSize(32, 32))
 	updateButton.Hide()
 
+	importButton := widget.NewButtonWithIcon("", theme.ContentPasteIcon(), nil)
+	importButton.Resize(fyne.NewSize(32, 32))
+	importButton.Disable()
+
 	searchEntry := widget.NewEntry()
 	searchEntry.SetPlaceHolder(messages.SearchGames)
 
@@ -95,7 +109,7 @@ func ShowUserInterface(cfg *internal.Config) {
 	manifestBadge := widget.NewLabel("❌ " + messages.ManifestStatus)
 
 	footer := container.NewHBox(
-		widget.NewLabel("Status:"),
+		widget.NewLabel(messages.Status),
 		layout.NewSpacer(),
 		r2modmanBadge,
 		steamBadge,
@@ -104,7 +118,7 @@ func ShowUserInterface(cfg *internal.Config) {
 
 	topSection := container.NewBorder(
 		nil, nil,
-		nil, container.NewHBox(updateButton, infoButton),
+		nil, container.NewHBox(updateButton, importButton, infoButton),
 		searchEntry,
 	)
 
@@ -120,27 +134,27 @@ func ShowUserInterface(cfg *internal.Config) {
 
 	go func() {
 		time.Sleep(2 * time.Second)
-		checkForUpdates(updateButton, messages)
+		checkForUpdates(updateButton, cfg, messages)
 
-		ticker := time.NewTicker(4 * time.Hour)
+		ticker := time.NewTicker(config.GetUpdateCheckInterval(cfg))
 		defer ticker.Stop()
 
 		for range ticker.C {
-			checkForUpdates(updateButton, messages)
+			checkForUpdates(updateButton, cfg, messages)
 		}
 	}()
 
 	go func() {
-		if _, err := r2modman.Find(); err == nil {
+		if managers := r2modman.Detect(); len(managers) > 0 {
 			fyne.Do(func() {
-				r2modmanBadge.SetText("✅ " + messages.R2ModmanStatus)
+				r2modmanBadge.SetText("✅ " + managers[0].Name)
 			})
 		}
 
 		var err error
 		steamApps, err = steam.GetApps()
 		if err != nil {
-			log.Printf("Warning: Could not load Steam apps: %v", err)
+			logger().Warn("Could not load Steam apps", "error", err)
 			steamApps = make(map[string]steam.App)
 		} else {
 			fyne.Do(func() {
@@ -148,9 +162,9 @@ func ShowUserInterface(cfg *internal.Config) {
 			})
 		}
 
-		games, err := profile.FetchGames(cfg.ManifestURL)
+		manifest, err := profile.FetchManifest(cfg.ManifestURL, config.GetHTTPTimeout(cfg))
 		if err != nil {
-			log.Printf("Manifest error: %v", err)
+			logger().Error("Manifest error", "error", err)
 			errorIcon := widget.NewIcon(theme.ErrorIcon())
 			errorLabel := widget.NewLabel(fmt.Sprintf("%s: %v", messages.Error, err))
 			errorContent := container.NewVBox(
@@ -164,6 +178,12 @@ func ShowUserInterface(cfg *internal.Config) {
 			return
 		}
 
+		games := manifest.Games
+		validationErrs := profile.ValidateManifest(manifest)
+		if len(validationErrs) > 0 {
+			logger().Warn("Manifest has problems", "count", len(validationErrs), "problems", validationErrs)
+		}
+
 		fyne.Do(func() {
 			manifestBadge.SetText(fmt.Sprintf("✅ %s (%d)", messages.ManifestStatus, len(games)))
 		})
@@ -189,49 +209,63 @@ func ShowUserInterface(cfg *internal.Config) {
 			gameRows = nil
 			filter = strings.ToLower(filter)
 
-			for _, game := range games {
+			for i, game := range games {
 				if filter != "" && !fuzzyMatch(strings.ToLower(game.Name), filter) {
 					continue
 				}
 
-				gameRow := createGameRow(game, steamApps, imageCache, messages, cfg, w)
+				var gameRow *fyne.Container
+				if gameErrs := validationErrs.ForGame(i); len(gameErrs) > 0 {
+					gameRow = createInvalidGameRow(game, gameErrs, messages, w)
+				} else {
+					gameRow = createGameRow(game, cfg, steamApps, imageCache, messages, store, w)
+				}
 				gameList.Add(gameRow)
 				gameRows = append(gameRows, gameRow)
 			}
 			gameList.Refresh()
 		}
 
-		searchEntry.OnChanged = updateGameList
-
-		updateGameList("")
-
 		fyne.Do(func() {
+			searchEntry.OnChanged = updateGameList
+			updateGameList("")
+
+			importButton.OnTapped = func() {
+				showImportCodeDialog(w, messages, store, games, func() {
+					updateGameList(searchEntry.Text)
+				})
+			}
+			importButton.Enable()
 			content.Objects = []fyne.CanvasObject{gameList}
 			content.Refresh()
 		})
 
 		go func() {
-			ticker := time.NewTicker(30 * time.Second)
+			ticker := time.NewTicker(config.GetManifestRefreshInterval(cfg))
 			defer ticker.Stop()
 
 			for range ticker.C {
-				freshGames, err := profile.FetchGames(cfg.ManifestURL)
-				if err == nil && len(freshGames) > 0 {
-					for i, freshGame := range freshGames {
-						if i < len(games) && games[i].Version != freshGame.Version {
-							log.Printf("Version change detected for %s: %s -> %s",
-								freshGame.Name, games[i].Version, freshGame.Version)
-						}
-					}
-					games = freshGames
-					fyne.Do(func() {
-						manifestBadge.SetText(fmt.Sprintf("✅ %s (%d)", messages.ManifestStatus, len(games)))
-					})
-				} else if err != nil {
-					log.Printf("Failed to refresh manifest: %v", err)
+				freshManifest, err := profile.FetchManifest(cfg.ManifestURL, config.GetHTTPTimeout(cfg))
+				if err != nil {
+					logger().Warn("Failed to refresh manifest", "error", err)
+				}
+				refreshed := err == nil && len(freshManifest.Games) > 0
+				var freshErrs profile.ValidationErrors
+				if refreshed {
+					freshErrs = profile.ValidateManifest(freshManifest)
 				}
 
 				fyne.Do(func() {
+					if refreshed {
+						for i, freshGame := range freshManifest.Games {
+							if i < len(games) && games[i].Version != freshGame.Version {
+								logger().Info("Version change detected", "game", freshGame.Name, "from", games[i].Version, "to", freshGame.Version)
+							}
+						}
+						games = freshManifest.Games
+						validationErrs = freshErrs
+						manifestBadge.SetText(fmt.Sprintf("✅ %s (%d)", messages.ManifestStatus, len(games)))
+					}
 					updateGameList(searchEntry.Text)
 				})
 			}
@@ -241,11 +275,11 @@ func ShowUserInterface(cfg *internal.Config) {
 	w.ShowAndRun()
 }
 
-func showInfoDialog(parent fyne.Window, messages internal.Messages) {
+func showInfoDialog(parent fyne.Window, cfg *internal.Config, store *profile.ProfileStore, messages internal.Messages) {
 	infoLabel := widget.NewRichTextFromMarkdown(messages.InfoContent)
 	infoLabel.Wrapping = fyne.TextWrapWord
 
-	updateCheckButton := widget.NewButtonWithIcon("Nach Updates suchen", theme.ViewRefreshIcon(), func() {
+	updateCheckButton := widget.NewButtonWithIcon(messages.CheckForUpdates, theme.ViewRefreshIcon(), func() {
 	})
 
 	updateCheckButton.OnTapped = func() {
@@ -255,28 +289,32 @@ func showInfoDialog(parent fyne.Window, messages internal.Messages) {
 				updateCheckButton.Disable()
 			})
 
-			updateInfo, err := updater.CheckForUpdates()
+			updateInfo, err := updater.CheckForUpdates(cfg)
 
 			fyne.Do(func() {
-				updateCheckButton.SetText("Nach Updates suchen")
+				updateCheckButton.SetText(messages.CheckForUpdates)
 				updateCheckButton.Enable()
 
 				if err != nil {
-					dialog.ShowError(fmt.Errorf("Update-Prüfung fehlgeschlagen: %v", err), parent)
+					dialog.ShowError(fmt.Errorf("%s: %v", messages.UpdateCheckFailed, err), parent)
 					return
 				}
 
 				if updateInfo.Available {
 					dialog.ShowInformation(messages.UpdateAvailable,
-						fmt.Sprintf("Neue Version verfügbar: %s", updateInfo.LatestVersion), parent)
+						fmt.Sprintf(messages.NewVersionAvailable, updateInfo.LatestVersion), parent)
 				} else {
 					dialog.ShowInformation(messages.NoUpdatesAvailable,
-						fmt.Sprintf("Sie verwenden bereits die neueste Version (%s)", updateInfo.CurrentVersion), parent)
+						fmt.Sprintf(messages.AlreadyLatest, updateInfo.CurrentVersion), parent)
 				}
 			})
 		}()
 	}
 
+	supportButton := widget.NewButtonWithIcon(messages.SupportBundle, theme.DocumentSaveIcon(), func() {
+		showSupportBundleDialog(parent, cfg, store, messages)
+	})
+
 	infoScroll := container.NewScroll(infoLabel)
 
 	windowWidth, windowHeight := GetWindowDimensions()
@@ -288,7 +326,7 @@ func showInfoDialog(parent fyne.Window, messages internal.Messages) {
 	content := container.NewVBox(
 		infoScroll,
 		widget.NewSeparator(),
-		container.NewCenter(updateCheckButton),
+		container.NewCenter(container.NewHBox(updateCheckButton, supportButton)),
 	)
 
 	infoDialog := dialog.NewCustom(
@@ -302,7 +340,11 @@ func showInfoDialog(parent fyne.Window, messages internal.Messages) {
 	infoDialog.Show()
 }
 
-func createGameRow(game internal.Game, steamApps map[string]steam.App, imageCache map[string]*fyne.StaticResource, messages internal.Messages, cfg *internal.Config, parent fyne.Window) *fyne.Container {
+func createGameRow(baseGame internal.Game, cfg *internal.Config, steamApps map[string]steam.App, imageCache map[string]*fyne.StaticResource, messages internal.Messages, store *profile.ProfileStore, parent fyne.Window) *fyne.Container {
+	variants := baseGame.ProfileVariants()
+	selectedVariant := loadSelectedVariant(baseGame, variants)
+	game := baseGame.WithVariant(variants[selectedVariant])
+
 	headerImg := canvas.NewImageFromResource(nil)
 	headerImg.SetMinSize(fyne.NewSize(92, 43))
 	headerImg.FillMode = canvas.ImageFillContain
@@ -314,7 +356,7 @@ func createGameRow(game internal.Game, steamApps map[string]steam.App, imageCach
 	imageContainer.Resize(fyne.NewSize(92, 43))
 
 	if game.Header != "" {
-		go loadGameIcon(game, headerImg, imageCache)
+		loadGameIcon(game, headerImg, imageCache, config.GetHTTPTimeout(cfg))
 	}
 
 	nameLabel := widget.NewLabel(game.Name)
@@ -325,19 +367,69 @@ func createGameRow(game internal.Game, steamApps map[string]steam.App, imageCach
 	actionBtn := widget.NewButton(messages.LoadingGames, nil)
 	actionBtn.Importance = widget.HighImportance
 
+	configBtn := widget.NewButtonWithIcon("", theme.SettingsIcon(), func() {
+		showConfigEditor(store, game, messages, parent)
+	})
+	configBtn.Hide()
+
+	healthBtn := widget.NewButtonWithIcon("", theme.InfoIcon(), func() {
+		showHealthPanel(store, game, messages)
+	})
+	healthBtn.Hide()
+
+	var updateRow func()
+
+	launchBtn := widget.NewButtonWithIcon("", theme.ComputerIcon(), func() {
+		showLaunchOptions(cfg, game, messages, parent, updateRow)
+	})
+
+	details := container.NewVBox(nameLabel)
+	if len(variants) > 1 {
+		variantNames := make([]string, len(variants))
+		for i, variant := range variants {
+			variantNames[i] = variant.Name
+		}
+
+		descriptionLabel := widget.NewLabel(variants[selectedVariant].Description)
+		descriptionLabel.Wrapping = fyne.TextWrapWord
+
+		variantSelect := widget.NewSelect(variantNames, nil)
+		variantSelect.PlaceHolder = messages.SelectVariant
+		variantSelect.SetSelectedIndex(selectedVariant)
+		variantSelect.OnChanged = func(string) {
+			index := variantSelect.SelectedIndex()
+			if index < 0 {
+				return
+			}
+			game = baseGame.WithVariant(variants[index])
+			descriptionLabel.SetText(variants[index].Description)
+			saveSelectedVariant(baseGame, variants[index].Name)
+			updateRow()
+		}
+
+		details.Add(variantSelect)
+		details.Add(descriptionLabel)
+	}
+
 	row := container.NewBorder(
 		nil, nil,
 		imageContainer,
-		actionBtn,
-		container.NewPadded(nameLabel),
+		container.NewHBox(launchBtn, healthBtn, configBtn, actionBtn),
+		container.NewPadded(details),
 	)
 
-	var updateRow func()
 	updateRow = func() {
-		isInstalled := steam.IsGameInstalled(game, steamApps)
+		isInstalled := steam.IsLaunchable(game, steamApps, config.GetGameLaunch(cfg, game))
 		isRunning := steam.IsGameRunning(game)
 
-		profileStatus := profile.GetProfileStatus(game, cfg.TargetDir)
+		profileStatus := store.GetProfileStatus(game)
+		if profileStatus.Installed {
+			configBtn.Show()
+			updateHealthButton(healthBtn, store, game)
+		} else {
+			configBtn.Hide()
+			healthBtn.Hide()
+		}
 
 		switch {
 		case !isInstalled:
@@ -364,13 +456,13 @@ func createGameRow(game internal.Game, steamApps map[string]steam.App, imageCach
 
 					fyne.Do(func() {
 						if err != nil {
-							log.Printf("Failed to stop %s: %v", game.Name, err)
+							logger().Error("Failed to stop game", "game", game.Name, "error", err)
 							dialog.ShowError(
 								fmt.Errorf("%s: %v", messages.StopFailed, err),
 								parent,
 							)
 						} else {
-							log.Printf("Successfully stopped %s", game.Name)
+							logger().Info("Stopped game", "game", game.Name)
 						}
 						updateRow()
 					})
@@ -378,11 +470,26 @@ func createGameRow(game internal.Game, steamApps map[string]steam.App, imageCach
 			}
 			return
 
+		case profileStatus.Installed && profileStatus.ReinstallRequired:
+			actionBtn.SetText(messages.ReinstallProfile)
+			actionBtn.SetIcon(theme.WarningIcon())
+			actionBtn.Importance = widget.WarningImportance
+
+		case profileStatus.Installed && profileStatus.HasDowngrade:
+			actionBtn.SetText(messages.DowngradeProfile)
+			actionBtn.SetIcon(theme.HistoryIcon())
+			actionBtn.Importance = widget.MediumImportance
+
 		case profileStatus.Installed && profileStatus.HasUpdate:
 			actionBtn.SetText(messages.UpdateProfile)
 			actionBtn.SetIcon(theme.ViewRefreshIcon())
 			actionBtn.Importance = widget.MediumImportance
 
+		case profileStatus.Installed && profileStatus.Incomplete:
+			actionBtn.SetText(messages.RepairProfile)
+			actionBtn.SetIcon(theme.WarningIcon())
+			actionBtn.Importance = widget.WarningImportance
+
 		case profileStatus.Installed && profileStatus.UpToDate:
 			actionBtn.SetText(messages.PlayWithProfile)
 			actionBtn.SetIcon(theme.MediaPlayIcon())
@@ -401,7 +508,7 @@ func createGameRow(game internal.Game, steamApps map[string]steam.App, imageCach
 
 		actionBtn.Enable()
 
-		currentProfileStatus := profile.GetProfileStatus(game, cfg.TargetDir)
+		currentProfileStatus := store.GetProfileStatus(game)
 
 		if !currentProfileStatus.Installed && game.URL != "" {
 			actionBtn.OnTapped = func() {
@@ -411,58 +518,91 @@ func createGameRow(game internal.Game, steamApps map[string]steam.App, imageCach
 				actionBtn.Disable()
 
 				go func() {
-					err := profile.DownloadAndInstall(game, cfg.TargetDir)
+					err := store.DownloadAndInstall(game)
 
 					fyne.Do(func() {
 						if err != nil {
-							log.Printf("Failed to install profile for %s: %v", game.Name, err)
+							logger().Error("Failed to install profile", "game", game.Name, "error", err)
 							dialog.ShowError(
 								fmt.Errorf("%s: %v", messages.InstallationFailed, err),
 								parent,
 							)
 						} else {
-							log.Printf("Successfully installed profile for %s", game.Name)
+							logger().Info("Installed profile", "game", game.Name)
 						}
 						updateRow()
 					})
 				}()
 			}
-		} else if currentProfileStatus.Installed && currentProfileStatus.HasUpdate && game.URL != "" {
+		} else if currentProfileStatus.Installed && game.URL != "" &&
+			(currentProfileStatus.HasUpdate || currentProfileStatus.HasDowngrade || currentProfileStatus.ReinstallRequired) {
 			actionBtn.OnTapped = func() {
-				actionBtn.SetText(messages.Updating)
+				actionBtn.SetText(messages.CheckingChanges)
 				actionBtn.SetIcon(theme.ViewRefreshIcon())
 				actionBtn.Importance = widget.MediumImportance
 				actionBtn.Disable()
 
 				go func() {
-					err := profile.DeleteProfile(game)
-					if err != nil {
-						log.Printf("Warning: Failed to delete old profile for %s: %v", game.Name, err)
-					}
-
-					err = profile.DownloadAndInstall(game, cfg.TargetDir)
+					diff, err := store.PreviewUpdate(game)
 
 					fyne.Do(func() {
 						if err != nil {
-							log.Printf("Failed to update profile for %s: %v", game.Name, err)
+							logger().Error("Failed to prepare profile update", "game", game.Name, "error", err)
 							dialog.ShowError(
 								fmt.Errorf("%s: %v", messages.UpdateFailed, err),
 								parent,
 							)
-						} else {
-							log.Printf("Successfully updated profile for %s", game.Name)
+							updateRow()
+							return
 						}
-						updateRow()
+
+						showProfileDiffDialog(parent, messages, game, diff, func(confirmed bool, policy profile.ConfigMergePolicy) {
+							if !confirmed {
+								updateRow()
+								return
+							}
+
+							actionBtn.SetText(messages.Updating)
+
+							go func() {
+								results, err := store.ApplyUpdate(game, diff, policy)
+
+								fyne.Do(func() {
+									if err != nil {
+										logger().Error("Failed to update profile", "game", game.Name, "error", err)
+										dialog.ShowError(
+											fmt.Errorf("%s: %v", messages.UpdateFailed, err),
+											parent,
+										)
+									} else {
+										logger().Info("Updated profile", "game", game.Name)
+										if len(results) > 0 {
+											showConfigMergeSummary(parent, messages, results)
+										}
+									}
+									updateRow()
+								})
+							}()
+						})
 					})
 				}()
 			}
+		} else if currentProfileStatus.Installed && currentProfileStatus.Incomplete {
+			actionBtn.OnTapped = func() {
+				actionBtn.SetText(messages.Repairing)
+				actionBtn.SetIcon(theme.ViewRefreshIcon())
+				actionBtn.Importance = widget.MediumImportance
+				actionBtn.Disable()
+
+				runRepair(store, game, messages, parent, updateRow)
+			}
 		} else {
 			actionBtn.OnTapped = func() {
 				go func() {
-					err := steam.LaunchGame(game, cfg.TargetDir, steamApps)
+					err := steam.LaunchGame(store, game, steamApps, config.GetGameLaunch(cfg, game))
 					fyne.Do(func() {
 						if err != nil {
-							log.Printf("Failed to launch %s: %v", game.Name, err)
+							logger().Error("Failed to launch game", "game", game.Name, "error", err)
 							dialog.ShowError(
 								fmt.Errorf("%s: %v", messages.LaunchFailed, err),
 								parent,
@@ -476,15 +616,194 @@ func createGameRow(game internal.Game, steamApps map[string]steam.App, imageCach
 
 	updateRow()
 
+	rowMenu := func() *fyne.Menu {
+		if !store.IsInstalled(game) {
+			return nil
+		}
+		return fyne.NewMenu("",
+			fyne.NewMenuItem(messages.VerifyProfile, func() {
+				showVerifyDialog(store, game, messages, parent, updateRow)
+			}),
+			fyne.NewMenuItem(messages.RepairProfile, func() {
+				runRepair(store, game, messages, parent, updateRow)
+			}),
+		)
+	}
+
 	rowWithSeparator := container.NewVBox(
-		container.NewPadded(row),
+		newContextMenuRow(container.NewPadded(row), rowMenu),
 		widget.NewSeparator(),
 	)
 
 	return rowWithSeparator
 }
 
-func loadGameIcon(game internal.Game, headerImg *canvas.Image, imageCache map[string]*fyne.StaticResource) {
+func showProfileDiffDialog(parent fyne.Window, messages internal.Messages, game internal.Game, diff *profile.ProfileDiff, onConfirm func(bool, profile.ConfigMergePolicy)) {
+	var content strings.Builder
+
+	fromVersion := diff.FromVersion
+	if fromVersion == "" {
+		fromVersion = "?"
+	}
+	fmt.Fprintf(&content, "**%s** %s → %s\n\n", game.Name, fromVersion, diff.ToVersion)
+
+	if diff.Changelog != "" {
+		fmt.Fprintf(&content, "**%s:**\n\n%s\n\n", messages.Changelog, diff.Changelog)
+	}
+
+	writeMods := func(title string, changes []profile.ModChange) {
+		if len(changes) == 0 {
+			return
+		}
+		fmt.Fprintf(&content, "**%s (%d):**\n\n", title, len(changes))
+		for _, change := range changes {
+			switch {
+			case change.FromVersion == "":
+				fmt.Fprintf(&content, "- %s %s\n", change.Name, change.ToVersion)
+			case change.ToVersion == "":
+				fmt.Fprintf(&content, "- %s %s\n", change.Name, change.FromVersion)
+			default:
+				fmt.Fprintf(&content, "- %s %s → %s\n", change.Name, change.FromVersion, change.ToVersion)
+			}
+		}
+		content.WriteString("\n")
+	}
+
+	writeFiles := func(title string, files []string) {
+		if len(files) == 0 {
+			return
+		}
+		fmt.Fprintf(&content, "**%s (%d):**\n\n", title, len(files))
+		for _, file := range files {
+			fmt.Fprintf(&content, "- %s\n", file)
+		}
+		content.WriteString("\n")
+	}
+
+	writeMods(messages.ModsAdded, diff.Added)
+	writeMods(messages.ModsRemoved, diff.Removed)
+	writeMods(messages.ModsUpgraded, diff.Upgraded)
+	writeMods(messages.ModsDowngraded, diff.Downgraded)
+	writeFiles(messages.ConfigAdded, diff.ConfigAdded)
+	writeFiles(messages.ConfigRemoved, diff.ConfigRemoved)
+	writeFiles(messages.ConfigChanged, diff.ConfigChanged)
+	writeFiles(messages.UserModifiedConfigs, diff.UserModified)
+
+	if !diff.HasModChanges() && !diff.HasConfigChanges() {
+		content.WriteString(messages.NoProfileChanges + "\n")
+	}
+
+	diffLabel := widget.NewRichTextFromMarkdown(content.String())
+	diffLabel.Wrapping = fyne.TextWrapWord
+
+	policies := []profile.ConfigMergePolicy{profile.ConfigMerge, profile.ConfigKeepUser, profile.ConfigTakeNew}
+	policyLabels := []string{messages.PolicyMerge, messages.PolicyKeepUser, messages.PolicyTakeNew}
+	policySelect := widget.NewSelect(policyLabels, nil)
+	policySelect.SetSelectedIndex(0)
+
+	body := container.NewBorder(
+		nil,
+		container.NewBorder(nil, nil, widget.NewLabel(messages.ConfigPolicy), nil, policySelect),
+		nil, nil,
+		container.NewScroll(diffLabel),
+	)
+
+	diffDialog := dialog.NewCustomConfirm(
+		messages.ProfileChangesTitle,
+		messages.UpdateProfile,
+		messages.Cancel,
+		body,
+		func(confirmed bool) {
+			policy := profile.ConfigMerge
+			if index := policySelect.SelectedIndex(); index >= 0 {
+				policy = policies[index]
+			}
+			onConfirm(confirmed, policy)
+		},
+		parent,
+	)
+	diffDialog.Resize(fyne.NewSize(500, 400))
+	diffDialog.Show()
+}
+
+func showConfigMergeSummary(parent fyne.Window, messages internal.Messages, results []profile.ConfigMergeResult) {
+	actionLabels := map[profile.ConfigMergeAction]string{
+		profile.ConfigActionKept:     messages.ConfigKept,
+		profile.ConfigActionReplaced: messages.ConfigReplaced,
+		profile.ConfigActionMerged:   messages.ConfigMerged,
+		profile.ConfigActionRestored: messages.ConfigRestored,
+	}
+
+	var content strings.Builder
+	for _, result := range results {
+		fmt.Fprintf(&content, "- **%s**: %s\n", result.Path, actionLabels[result.Action])
+		for _, conflict := range result.Conflicts {
+			fmt.Fprintf(&content, "    - [%s] %s: %s = %s (%s: %s)\n",
+				conflict.Section, conflict.Key, messages.ConfigYourValue, conflict.UserValue, messages.ConfigProfileValue, conflict.NewValue)
+		}
+	}
+
+	summaryLabel := widget.NewRichTextFromMarkdown(content.String())
+	summaryLabel.Wrapping = fyne.TextWrapWord
+
+	summaryDialog := dialog.NewCustom(messages.ConfigSummaryTitle, messages.Close, container.NewScroll(summaryLabel), parent)
+	summaryDialog.Resize(fyne.NewSize(500, 350))
+	summaryDialog.Show()
+}
+
+func variantPreferenceKey(game internal.Game) string {
+	return fmt.Sprintf("variant.%s.%s", game.ID, game.Name)
+}
+
+func loadSelectedVariant(game internal.Game, variants []internal.ProfileVariant) int {
+	name := fyne.CurrentApp().Preferences().String(variantPreferenceKey(game))
+	for i, variant := range variants {
+		if variant.Name == name {
+			return i
+		}
+	}
+	return 0
+}
+
+func saveSelectedVariant(game internal.Game, name string) {
+	fyne.CurrentApp().Preferences().SetString(variantPreferenceKey(game), name)
+	logger().Info("Selected profile variant", "variant", name, "game", game.Name)
+}
+
+func createInvalidGameRow(game internal.Game, errs profile.ValidationErrors, messages internal.Messages, parent fyne.Window) *fyne.Container {
+	name := game.Name
+	if name == "" {
+		name = game.ID
+	}
+
+	nameLabel := widget.NewLabel(name)
+	nameLabel.TextStyle = fyne.TextStyle{Bold: true}
+	nameLabel.Wrapping = fyne.TextWrapWord
+
+	var details strings.Builder
+	for _, err := range errs {
+		fmt.Fprintf(&details, "• %s: %v\n", err.Field, err.Err)
+	}
+
+	detailsBtn := widget.NewButtonWithIcon(messages.InvalidEntry, theme.WarningIcon(), func() {
+		dialog.ShowInformation(messages.InvalidEntryTitle, details.String(), parent)
+	})
+	detailsBtn.Importance = widget.WarningImportance
+
+	row := container.NewBorder(
+		nil, nil,
+		widget.NewIcon(theme.ErrorIcon()),
+		detailsBtn,
+		container.NewPadded(nameLabel),
+	)
+
+	return container.NewVBox(
+		container.NewPadded(row),
+		widget.NewSeparator(),
+	)
+}
+
+func loadGameIcon(game internal.Game, headerImg *canvas.Image, imageCache map[string]*fyne.StaticResource, timeout time.Duration) {
 	if cached, exists := imageCache[game.Name]; exists {
 		headerImg.Resource = cached
 		headerImg.Refresh()
@@ -492,45 +811,44 @@ func loadGameIcon(game internal.Game, headerImg *canvas.Image, imageCache map[st
 	}
 
 	go func() {
-		log.Printf("Loading image for %s: %s", game.Name, game.Header)
-		client := &http.Client{Timeout: 10 * time.Second}
+		logger().Debug("Loading image", "game", game.Name, "url", game.Header)
+		client := &http.Client{Timeout: timeout}
 		resp, err := client.Get(game.Header)
 		if err != nil {
-			log.Printf("Failed to fetch image for %s: %v", game.Name, err)
+			logger().Warn("Failed to fetch image", "game", game.Name, "error", err)
 			return
 		}
 		defer resp.Body.Close()
 
 		if resp.StatusCode != http.StatusOK {
-			log.Printf("Bad status for image %s: %d", game.Name, resp.StatusCode)
+			logger().Warn("Bad status for image", "game", game.Name, "status", resp.StatusCode)
 			return
 		}
 
 		imgData, err := io.ReadAll(resp.Body)
 		if err != nil {
-			log.Printf("Failed to read image data for %s: %v", game.Name, err)
+			logger().Warn("Failed to read image data", "game", game.Name, "error", err)
 			return
 		}
 
-		log.Printf("Loaded %d bytes for image %s", len(imgData), game.Name)
+		logger().Debug("Loaded image", "game", game.Name, "bytes", len(imgData))
 
 		resource := fyne.NewStaticResource(game.Name+"_header", imgData)
 
-		imageCache[game.Name] = resource
-
 		fyne.Do(func() {
+			imageCache[game.Name] = resource
 			headerImg.Resource = resource
 			headerImg.Refresh()
-			log.Printf("Updated image for %s", game.Name)
+			logger().Debug("Updated image", "game", game.Name)
 		})
 	}()
 }
 
-func checkForUpdates(updateButton *widget.Button, messages internal.Messages) {
+func checkForUpdates(updateButton *widget.Button, cfg *internal.Config, messages internal.Messages) {
 	go func() {
-		updateInfo, err := updater.CheckForUpdates()
+		updateInfo, err := updater.CheckForUpdates(cfg)
 		if err != nil {
-			log.Printf("Failed to check for updates: %v", err)
+			logger().Warn("Failed to check for updates", "error", err)
 			return
 		}
 
@@ -543,8 +861,8 @@ func checkForUpdates(updateButton *widget.Button, messages internal.Messages) {
 	}()
 }
 
-func showUpdateDialog(parent fyne.Window, messages internal.Messages) {
-	updateInfo, err := updater.CheckForUpdates()
+func showUpdateDialog(parent fyne.Window, cfg *internal.Config, messages internal.Messages) {
+	updateInfo, err := updater.CheckForUpdates(cfg)
 	if err != nil {
 		dialog.ShowError(err, parent)
 		return
@@ -552,19 +870,11 @@ func showUpdateDialog(parent fyne.Window, messages internal.Messages) {
 
 	if !updateInfo.Available {
 		dialog.ShowInformation(messages.NoUpdatesAvailable,
-			fmt.Sprintf("Sie verwenden bereits die neueste Version (%s)", updateInfo.CurrentVersion), parent)
+			fmt.Sprintf(messages.AlreadyLatest, updateInfo.CurrentVersion), parent)
 		return
 	}
 
-	content := fmt.Sprintf(`**Neue Version verfügbar!**
-
-**Aktuelle Version:** %s
-**Neue Version:** %s
-
-**Änderungen:**
-%s
-
-Möchten Sie jetzt aktualisieren?`,
+	content := fmt.Sprintf(messages.UpdateDetails,
 		updateInfo.CurrentVersion,
 		updateInfo.LatestVersion,
 		updateInfo.ReleaseNotes)
@@ -582,7 +892,7 @@ Möchten Sie jetzt aktualisieren?`,
 		updateScroll,
 		func(update bool) {
 			if update {
-				performUpdate(parent, messages, updateInfo)
+				performUpdate(parent, cfg, messages, updateInfo)
 			}
 		},
 		parent,
@@ -592,13 +902,17 @@ Möchten Sie jetzt aktualisieren?`,
 	updateDialog.Show()
 }
 
-func performUpdate(parent fyne.Window, messages internal.Messages, updateInfo *updater.UpdateInfo) {
+func performUpdate(parent fyne.Window, cfg *internal.Config, messages internal.Messages, updateInfo *updater.UpdateInfo) {
+	ctx, cancel := context.WithCancel(context.Background())
+
 	progressBar := widget.NewProgressBar()
 	progressLabel := widget.NewLabel(messages.UpdateDownloading)
+	cancelBtn := widget.NewButtonWithIcon(messages.Cancel, theme.CancelIcon(), cancel)
 
 	progressContent := container.NewVBox(
 		progressLabel,
 		progressBar,
+		container.NewCenter(cancelBtn),
 	)
 
 	progressDialog := dialog.NewCustomWithoutButtons(
@@ -609,17 +923,30 @@ func performUpdate(parent fyne.Window, messages internal.Messages, updateInfo *u
 	progressDialog.Show()
 
 	go func() {
-		tempFile, err := updater.DownloadUpdate(updateInfo.DownloadURL, func(downloaded, total int64) {
-			if total > 0 {
-				progress := float64(downloaded) / float64(total)
-				fyne.Do(func() {
-					progressBar.SetValue(progress)
-					progressLabel.SetText(fmt.Sprintf("%s (%d%%)",
-						messages.UpdateDownloading, int(progress*100)))
-				})
-			}
+		defer cancel()
+
+		tempFile, err := updater.DownloadUpdate(ctx, updateInfo.DownloadURL, updater.DownloadOptions{
+			BytesPerSecond: config.GetDownloadLimit(cfg),
+			Progress: func(downloaded, total int64) {
+				if total > 0 {
+					progress := float64(downloaded) / float64(total)
+					fyne.Do(func() {
+						progressBar.SetValue(progress)
+						progressLabel.SetText(fmt.Sprintf("%s (%d%%)",
+							messages.UpdateDownloading, int(progress*100)))
+					})
+				}
+			},
 		})
 
+		if errors.Is(err, context.Canceled) {
+			logger().Info("Update download cancelled")
+			fyne.Do(func() {
+				progressDialog.Hide()
+				dialog.ShowInformation(messages.UpdateDialogTitle, messages.UpdateCancelled, parent)
+			})
+			return
+		}
 		if err != nil {
 			fyne.Do(func() {
 				progressDialog.Hide()
@@ -629,15 +956,30 @@ func performUpdate(parent fyne.Window, messages internal.Messages, updateInfo *u
 		}
 
 		fyne.Do(func() {
+			cancelBtn.Disable()
 			progressBar.SetValue(1.0)
-			progressLabel.SetText(messages.UpdateInstalling)
+			progressLabel.SetText(messages.UpdateVerifying)
 		})
 
-		if err := updater.ApplyUpdate(tempFile); err != nil {
+		update, err := updater.ApplyUpdate(updateInfo, tempFile)
+		if err == nil {
+			fyne.Do(func() {
+				progressLabel.SetText(messages.UpdateRestart)
+			})
+			err = update.Restart()
+		}
+		if err != nil {
+			logger().Error("Update failed", "error", err)
 			fyne.Do(func() {
 				progressDialog.Hide()
 				dialog.ShowError(fmt.Errorf("%s: %v", messages.UpdateError, err), parent)
 			})
+			return
 		}
+
+		logger().Info("Exiting for update")
+		fyne.Do(func() {
+			fyne.CurrentApp().Quit()
+		})
 	}()
 }
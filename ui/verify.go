@@ -0,0 +1,140 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/ur-wesley/modhelper/internal"
+	"github.com/ur-wesley/modhelper/internal/profile"
+)
+
+const maxListedProblems = 15
+
+type contextMenuRow struct {
+	widget.BaseWidget
+
+	content fyne.CanvasObject
+	menu    func() *fyne.Menu
+}
+
+func newContextMenuRow(content fyne.CanvasObject, menu func() *fyne.Menu) *contextMenuRow {
+	row := &contextMenuRow{content: content, menu: menu}
+	row.ExtendBaseWidget(row)
+	return row
+}
+
+func (r *contextMenuRow) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(r.content)
+}
+
+func (r *contextMenuRow) TappedSecondary(event *fyne.PointEvent) {
+	menu := r.menu()
+	if menu == nil {
+		return
+	}
+	widget.ShowPopUpMenuAtPosition(menu, fyne.CurrentApp().Driver().CanvasForObject(r), event.AbsolutePosition)
+}
+
+func showVerifyDialog(store *profile.ProfileStore, game internal.Game, messages internal.Messages, parent fyne.Window, onDone func()) {
+	progress := dialog.NewCustomWithoutButtons(messages.VerifyProfile, widget.NewLabel(messages.Verifying), parent)
+	progress.Show()
+
+	go func() {
+		result, err := store.Verify(game)
+
+		fyne.Do(func() {
+			progress.Hide()
+			if err != nil {
+				logger().Error("Failed to verify profile", "game", game.Name, "error", err)
+				dialog.ShowError(err, parent)
+				return
+			}
+
+			text := formatVerifyResult(result, messages)
+			if result.OK() {
+				dialog.ShowInformation(messages.VerifyProfile, text, parent)
+				return
+			}
+
+			dialog.ShowConfirm(messages.VerifyProfile, text+"\n\n"+messages.RepairQuestion, func(confirmed bool) {
+				if confirmed {
+					runRepair(store, game, messages, parent, onDone)
+				}
+			}, parent)
+		})
+	}()
+}
+
+func runRepair(store *profile.ProfileStore, game internal.Game, messages internal.Messages, parent fyne.Window, onDone func()) {
+	progress := dialog.NewCustomWithoutButtons(messages.RepairProfile, widget.NewLabel(messages.Repairing), parent)
+	progress.Show()
+
+	go func() {
+		result, err := store.Repair(game)
+
+		fyne.Do(func() {
+			progress.Hide()
+			defer onDone()
+
+			if err != nil {
+				logger().Error("Failed to repair profile", "game", game.Name, "error", err)
+				dialog.ShowError(fmt.Errorf("%s: %v", messages.RepairFailed, err), parent)
+				return
+			}
+			if !result.OK() {
+				dialog.ShowInformation(messages.RepairFailed, formatVerifyResult(result, messages), parent)
+				return
+			}
+
+			logger().Info("Repaired profile", "game", game.Name)
+			dialog.ShowInformation(messages.RepairProfile, messages.RepairDone, parent)
+		})
+	}()
+}
+
+func formatVerifyResult(result *profile.VerifyResult, messages internal.Messages) string {
+	var text strings.Builder
+
+	if result.OK() {
+		text.WriteString(messages.VerifyOK.Format(result.Checked, result.Checked))
+	} else {
+		text.WriteString(messages.VerifyProblems.Format(len(result.Problems), len(result.Problems)))
+		for i, problem := range result.Problems {
+			if i == maxListedProblems {
+				text.WriteString(fmt.Sprintf("\n… (+%d)", len(result.Problems)-maxListedProblems))
+				break
+			}
+
+			subject := problem.Path
+			if subject == "" {
+				subject = problem.Mod
+			}
+			text.WriteString(fmt.Sprintf("\n• %s: %s", subject, problemText(problem.Kind, messages)))
+		}
+	}
+
+	if !result.Indexed {
+		text.WriteString("\n\n" + messages.VerifyNotIndexed)
+	}
+	return text.String()
+}
+
+func problemText(kind profile.ProblemKind, messages internal.Messages) string {
+	switch kind {
+	case profile.ProblemMissing:
+		return messages.ProblemMissing
+	case profile.ProblemCorrupt:
+		return messages.ProblemCorrupt
+	case profile.ProblemConfigMissing:
+		return messages.ProblemConfigMissing
+	case profile.ProblemEssentialMissing:
+		return messages.ProblemEssentialMissing
+	case profile.ProblemModMissing:
+		return messages.ProblemModMissing
+	}
+	return string(kind)
+}